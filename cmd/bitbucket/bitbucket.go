@@ -18,7 +18,8 @@ import "github.com/alecthomas/kingpin/v2"
 
 func Register(app *kingpin.Application) {
 	cmd := app.Command("bitbucket", "migrate bitbucket data")
-	//registerImport(cmd)
 	registerConvert(cmd)
 	registerGit(cmd)
+	registerExport(cmd)
+	registerImport(cmd)
 }
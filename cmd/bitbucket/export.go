@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/harness/harness-migrate/cmd/util"
+	"github.com/harness/harness-migrate/internal/migrate/bitbucket"
+	"github.com/harness/harness-migrate/internal/tracer"
+
+	"github.com/alecthomas/kingpin/v2"
+)
+
+type pipelineExportCommand struct {
+	debug bool
+	file  string
+
+	workspace      string
+	repositoryList string
+	token          string
+	url            string
+	skipVerify     bool
+
+	kubeName   string
+	kubeConn   string
+	dockerConn string
+}
+
+func (c *pipelineExportCommand) run(*kingpin.ParseContext) error {
+	// create the logger
+	log := util.CreateLogger(c.debug)
+
+	// attach the logger to the context
+	ctx := context.Background()
+	ctx = util.WithLogger(ctx, log)
+
+	// create the tracer
+	tracer_ := tracer.New()
+	defer tracer_.Close()
+
+	client := util.CreateClient("", "", c.token, "", "", c.url, c.skipVerify)
+
+	var repositories []string
+	if c.repositoryList != "" {
+		repositories = strings.Split(c.repositoryList, ",")
+	}
+
+	// extract the data
+	exporter := &bitbucket.PipelineExporter{
+		Bitbucket:      client,
+		Workspace:      c.workspace,
+		RepositoryList: repositories,
+		DockerConn:     c.dockerConn,
+		KubeName:       c.kubeName,
+		KubeConn:       c.kubeConn,
+		Tracer:         tracer_,
+	}
+	data, err := exporter.Export(ctx)
+	if err != nil {
+		log.Error("Failed to extract data: ", err)
+		return err
+	}
+
+	// if no file path is provided, write the data export
+	// to stdout.
+	if c.file == "" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	}
+
+	// else write the data export to the file.
+	file, err := json.MarshalIndent(data, "", " ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.file, file, 0644)
+}
+
+// helper function registers the export command
+func registerExport(app *kingpin.CmdClause) {
+	c := new(pipelineExportCommand)
+
+	cmd := app.Command("export", "export bitbucket pipelines of a workspace").
+		Action(c.run)
+
+	cmd.Arg("save", "save the output to a file").
+		StringVar(&c.file)
+
+	cmd.Flag("workspace", "bitbucket workspace").
+		Required().
+		Envar("BITBUCKET_WORKSPACE").
+		StringVar(&c.workspace)
+
+	cmd.Flag("repository-list", "optional list of repositories to export").
+		Envar("REPOSITORY_LIST").
+		StringVar(&c.repositoryList)
+
+	cmd.Flag("bitbucket-token", "bitbucket token").
+		Required().
+		Envar("BITBUCKET_TOKEN").
+		StringVar(&c.token)
+
+	cmd.Flag("bitbucket-url", "bitbucket url").
+		Envar("BITBUCKET_URL").
+		StringVar(&c.url)
+
+	cmd.Flag("skip-tls-verify", "skip TLS verification for SCM").
+		Envar("SKIP_TLS_VERIFY").
+		BoolVar(&c.skipVerify)
+
+	cmd.Flag("kube-connector", "kubernetes connector").
+		StringVar(&c.kubeConn)
+
+	cmd.Flag("kube-namespace", "kubernetes namespace").
+		StringVar(&c.kubeName)
+
+	cmd.Flag("docker-connector", "dockerhub connector").
+		StringVar(&c.dockerConn)
+
+	cmd.Flag("debug", "enable debug logging").
+		BoolVar(&c.debug)
+}
@@ -17,6 +17,7 @@ package circle
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
 
 	"github.com/harness/harness-migrate/cmd/util"
@@ -25,6 +26,7 @@ import (
 
 	"github.com/alecthomas/kingpin/v2"
 
+	"github.com/harness/harness-migrate/internal/harness"
 	"github.com/harness/harness-migrate/internal/tracer"
 	"github.com/harness/harness-migrate/internal/types"
 )
@@ -45,6 +47,14 @@ type importCommand struct {
 	bitbucketToken string
 	bitbucketURL   string
 	skipVerify     bool
+
+	// store pipelines in the repository
+	remote       bool
+	remoteBranch string
+
+	// import into gitness
+	gitness          bool
+	gitnessRepoSpace string
 }
 
 func (c *importCommand) run(*kingpin.ParseContext) error {
@@ -56,6 +66,10 @@ func (c *importCommand) run(*kingpin.ParseContext) error {
 	ctx := context.Background()
 	ctx = util.WithLogger(ctx, log)
 
+	if c.harnessAccount == "" && !c.gitness {
+		return errors.New("harness account is required")
+	}
+
 	// read the data file
 	data, err := os.ReadFile(c.file)
 	if err != nil {
@@ -85,6 +99,17 @@ func (c *importCommand) run(*kingpin.ParseContext) error {
 		c.harnessAddress,
 	)
 	importer.Tracer = tracer_
+	importer.Remote = c.remote
+	importer.RemoteBranch = c.remoteBranch
+
+	// gitness pipelines are stored in the repository
+	// and need no scm user for connectors.
+	if c.gitness {
+		importer.Harness = harness.NewGitness(c.harnessToken, c.harnessAddress)
+		importer.Gitness = true
+		importer.GitnessRepoSpace = c.gitnessRepoSpace
+		return importer.Import(ctx, org)
+	}
 
 	// create a scm client to verify the token
 	// and retrieve the user id.
@@ -129,8 +154,7 @@ func registerImport(app *kingpin.CmdClause) {
 	cmd.Arg("file", "data file to import").
 		StringVar(&c.file)
 
-	cmd.Flag("harness-account", "harness account").
-		Required().
+	cmd.Flag("harness-account", "harness account, not required for gitness").
 		Envar("HARNESS_ACCOUNT").
 		StringVar(&c.harnessAccount)
 
@@ -179,4 +203,20 @@ func registerImport(app *kingpin.CmdClause) {
 
 	cmd.Flag("debug", "enable debug logging").
 		BoolVar(&c.debug)
+
+	cmd.Flag("remote", "store pipelines in the repository .harness folder").
+		Envar("HARNESS_REMOTE").
+		BoolVar(&c.remote)
+
+	cmd.Flag("remote-branch", "branch to commit remote pipelines, defaults to the repository branch").
+		Envar("HARNESS_REMOTE_BRANCH").
+		StringVar(&c.remoteBranch)
+
+	cmd.Flag("gitness", "import into a Gitness instance at the harness address").
+		Envar("GITNESS").
+		BoolVar(&c.gitness)
+
+	cmd.Flag("gitness-repo-space", "space of the gitness repositories storing the pipelines, defaults to the project space").
+		Envar("GITNESS_REPO_SPACE").
+		StringVar(&c.gitnessRepoSpace)
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/harness/harness-migrate/cmd/util"
 	"github.com/harness/harness-migrate/internal/migrate/drone"
 	"github.com/harness/harness-migrate/internal/migrate/drone/repo"
+	"github.com/harness/harness-migrate/internal/report"
 	"github.com/harness/harness-migrate/internal/tracer"
 
 	"github.com/alecthomas/kingpin/v2"
@@ -47,6 +48,8 @@ type exportCommand struct {
 	bitbucketToken string
 	bitbucketURL   string
 	skipVerify     bool
+
+	databaseSecret string
 }
 
 func (c *exportCommand) run(*kingpin.ParseContext) error {
@@ -94,6 +97,15 @@ func (c *exportCommand) run(*kingpin.ParseContext) error {
 		repository = strings.Split(c.repositoryList, ",")
 	}
 
+	var decrypter drone.Decrypter
+	if c.databaseSecret != "" {
+		decrypter, err = drone.NewDecrypter(c.databaseSecret)
+		if err != nil {
+			log.Error("Invalid database secret: ", err)
+			return err
+		}
+	}
+
 	log.Info("Extracting data...")
 
 	// extract the data
@@ -104,12 +116,15 @@ func (c *exportCommand) run(*kingpin.ParseContext) error {
 		ScmClient:      client,
 		ScmLogin:       user.Login,
 		RepositoryList: repository,
+		Decrypter:      decrypter,
+		Report:         make(map[string]*report.Report),
 	}
 	data, err := exporter.Export(ctx)
 	if err != nil {
 		log.Error("Failed to extract data: ", err)
 		return err
 	}
+	report.PublishReports(exporter.Report)
 
 	//if no file path is provided, write the data export
 	//to stdout.
@@ -161,6 +176,10 @@ func registerExport(app *kingpin.CmdClause) {
 		Default("database.sqlite3").
 		StringVar(&c.Datasource)
 
+	cmd.Flag("database-secret", "drone database secret used to decrypt secrets").
+		Envar("DRONE_DATABASE_SECRET").
+		StringVar(&c.databaseSecret)
+
 	cmd.Flag("github-token", "github token").
 		Envar("GITHUB_TOKEN").
 		StringVar(&c.githubToken)
@@ -23,6 +23,7 @@ import (
 
 	"github.com/drone/go-scm/scm"
 	"github.com/harness/harness-migrate/cmd/util"
+	"github.com/harness/harness-migrate/internal/harness"
 	"github.com/harness/harness-migrate/internal/migrate"
 	"github.com/harness/harness-migrate/internal/tracer"
 	"github.com/harness/harness-migrate/internal/types"
@@ -61,13 +62,25 @@ type importCommand struct {
 	dockerConn string
 
 	downgrade bool
+
+	// store pipelines in the repository
+	remote       bool
+	remoteBranch string
+
+	// import into gitness
+	gitness          bool
+	gitnessRepoSpace string
 }
 
 func (c *importCommand) run(*kingpin.ParseContext) error {
 	log := util.CreateLogger(c.debug)
 	ctx := util.WithLogger(context.Background(), log)
 
-	if c.repoConn == "" && (c.gitlabToken == "" && c.githubToken == "" && c.bitbucketToken == "") {
+	if c.harnessAccount == "" && !c.gitness {
+		return errors.New("harness account is required")
+	}
+
+	if c.repoConn == "" && !c.gitness && (c.gitlabToken == "" && c.githubToken == "" && c.bitbucketToken == "") {
 		return errors.New("either specify a repo connector or a gitlab/github/bitbucket token")
 	}
 
@@ -119,11 +132,23 @@ func (c *importCommand) createImporter(log *slog.Logger, ctx context.Context) (*
 	importer.DockerConn = c.dockerConn
 	importer.KubeName = c.kubeName
 	importer.KubeConn = c.kubeConn
+	importer.Remote = c.remote
+	importer.RemoteBranch = c.remoteBranch
 
 	if c.repositoryList != "" {
 		importer.RepositoryList = strings.Split(c.repositoryList, ",")
 	}
 
+	// gitness pipelines use the v1 yaml and are stored
+	// in the repository, without connectors.
+	if c.gitness {
+		importer.Harness = harness.NewGitness(c.harnessToken, c.harnessAddress)
+		importer.Gitness = true
+		importer.GitnessRepoSpace = c.gitnessRepoSpace
+		importer.Downgrade = false
+		return importer, nil
+	}
+
 	if c.repoConn == "" {
 		client, user, err := c.createAndVerifyScmClient(log, ctx)
 		if err != nil {
@@ -169,8 +194,7 @@ func registerImport(app *kingpin.CmdClause) {
 	cmd.Arg("file", "data file to import").
 		StringVar(&c.file)
 
-	cmd.Flag("harness-account", "harness account").
-		Required().
+	cmd.Flag("harness-account", "harness account, not required for gitness").
 		Envar("HARNESS_ACCOUNT").
 		StringVar(&c.harnessAccount)
 
@@ -220,6 +244,22 @@ func registerImport(app *kingpin.CmdClause) {
 	cmd.Flag("debug", "enable debug logging").
 		BoolVar(&c.debug)
 
+	cmd.Flag("remote", "store pipelines in the repository .harness folder").
+		Envar("HARNESS_REMOTE").
+		BoolVar(&c.remote)
+
+	cmd.Flag("remote-branch", "branch to commit remote pipelines, defaults to the repository branch").
+		Envar("HARNESS_REMOTE_BRANCH").
+		StringVar(&c.remoteBranch)
+
+	cmd.Flag("gitness", "import into a Gitness instance at the harness address").
+		Envar("GITNESS").
+		BoolVar(&c.gitness)
+
+	cmd.Flag("gitness-repo-space", "space of the gitness repositories storing the pipelines, defaults to the project space").
+		Envar("GITNESS_REPO_SPACE").
+		StringVar(&c.gitnessRepoSpace)
+
 	cmd.Flag("downgrade", "downgrade to the legacy yaml format").
 		Default("true").
 		BoolVar(&c.downgrade)
@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drone
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/harness-migrate/cmd/util"
+)
+
+func TestCreateImporterGitnessRepositoryList(t *testing.T) {
+	c := &importCommand{
+		harnessToken:   "dummy0d0ac576df34be6a882",
+		harnessAddress: "https://gitness.example.com",
+		repositoryList: "hello-world,spoon-knife",
+		gitness:        true,
+	}
+	importer, err := c.createImporter(util.CreateLogger(false), context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !importer.Gitness {
+		t.Errorf("Want gitness importer")
+	}
+	if got := importer.RepositoryList; len(got) != 2 || got[0] != "hello-world" || got[1] != "spoon-knife" {
+		t.Errorf("Want repository list with gitness, got %v", got)
+	}
+}
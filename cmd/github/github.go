@@ -20,4 +20,6 @@ func Register(app *kingpin.Application) {
 	cmd := app.Command("github", "migrate github data")
 	registerConvert(cmd)
 	registerGit(cmd)
+	registerExport(cmd)
+	registerImport(cmd)
 }
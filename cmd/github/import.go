@@ -0,0 +1,196 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/harness/harness-migrate/cmd/util"
+	"github.com/harness/harness-migrate/internal/report"
+	"github.com/harness/harness-migrate/internal/tracer"
+	"github.com/harness/harness-migrate/internal/types"
+
+	"github.com/alecthomas/kingpin/v2"
+	"golang.org/x/exp/slog"
+)
+
+type pipelineImportCommand struct {
+	debug bool
+	file  string
+
+	harnessToken   string
+	harnessAccount string
+	harnessOrg     string
+	harnessAddress string
+
+	repositoryList string
+
+	githubToken string
+	githubURL   string
+	skipVerify  bool
+
+	repoConn   string
+	kubeName   string
+	kubeConn   string
+	dockerConn string
+
+	downgrade bool
+}
+
+func (c *pipelineImportCommand) run(*kingpin.ParseContext) error {
+	// create the logger
+	log := util.CreateLogger(c.debug)
+
+	// attach the logger to the context
+	ctx := context.Background()
+	ctx = util.WithLogger(ctx, log)
+
+	// read the data file
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		log.Error("cannot read data file", nil)
+		return err
+	}
+
+	// unmarshal the data file
+	org := new(types.Org)
+	if err := json.Unmarshal(data, org); err != nil {
+		log.Error("cannot unmarshal data file", nil)
+		return err
+	}
+
+	// create the tracer
+	tracer_ := tracer.New()
+	defer tracer_.Close()
+
+	// create the importer
+	importer := util.CreateImporter(
+		c.harnessAccount,
+		c.harnessOrg,
+		c.harnessToken,
+		c.githubToken,
+		"",
+		"",
+		c.harnessAddress,
+	)
+	importer.Tracer = tracer_
+	importer.Downgrade = c.downgrade
+	importer.DockerConn = c.dockerConn
+	importer.KubeName = c.kubeName
+	importer.KubeConn = c.kubeConn
+	importer.Report = make(map[string]*report.Report)
+
+	if c.repositoryList != "" {
+		importer.RepositoryList = strings.Split(c.repositoryList, ",")
+	}
+
+	if c.repoConn != "" {
+		importer.RepoConn = c.repoConn
+	} else {
+		// create a scm client to verify the token
+		// and retrieve the user id.
+		client := util.CreateClient(c.githubToken, "", "", c.githubURL, "", "", c.skipVerify)
+		user, _, err := client.Users.Find(ctx)
+		if err != nil {
+			log.Error("cannot retrieve git user", nil)
+			return err
+		}
+		log.Debug("verified user and token",
+			slog.String("user", user.Login),
+		)
+		importer.ScmClient = client
+		importer.ScmLogin = user.Login
+	}
+
+	// execute the import routine.
+	if err := importer.Import(ctx, org); err != nil {
+		return err
+	}
+	report.PublishReports(importer.Report)
+	return nil
+}
+
+// helper function registers the import command.
+func registerImport(app *kingpin.CmdClause) {
+	c := new(pipelineImportCommand)
+
+	cmd := app.Command("import", "import github actions pipelines").
+		Action(c.run)
+
+	cmd.Arg("file", "data file to import").
+		Required().
+		StringVar(&c.file)
+
+	cmd.Flag("harness-account", "harness account").
+		Required().
+		Envar("HARNESS_ACCOUNT").
+		StringVar(&c.harnessAccount)
+
+	cmd.Flag("harness-org", "harness organization").
+		Required().
+		Envar("HARNESS_ORG").
+		StringVar(&c.harnessOrg)
+
+	cmd.Flag("harness-token", "harness token").
+		Required().
+		Envar("HARNESS_TOKEN").
+		StringVar(&c.harnessToken)
+
+	cmd.Flag("harness-address", "harness address").
+		Envar("HARNESS_ADDRESS").
+		Default("https://app.harness.io").
+		StringVar(&c.harnessAddress)
+
+	cmd.Flag("github-token", "github token").
+		Required().
+		Envar("GITHUB_TOKEN").
+		StringVar(&c.githubToken)
+
+	cmd.Flag("github-url", "github url").
+		Envar("GITHUB_URL").
+		StringVar(&c.githubURL)
+
+	cmd.Flag("skip-tls-verify", "skip TLS verification for SCM").
+		Envar("SKIP_TLS_VERIFY").
+		BoolVar(&c.skipVerify)
+
+	cmd.Flag("downgrade", "downgrade to the legacy yaml format").
+		Default("true").
+		BoolVar(&c.downgrade)
+
+	cmd.Flag("kube-connector", "kubernetes connector").
+		Envar("KUBE_CONN").
+		StringVar(&c.kubeConn)
+
+	cmd.Flag("kube-namespace", "kubernetes namespace").
+		Envar("KUBE_NAMESPACE").
+		StringVar(&c.kubeName)
+
+	cmd.Flag("docker-connector", "dockerhub connector").
+		StringVar(&c.dockerConn)
+
+	cmd.Flag("repo-connector", "repository connector").
+		StringVar(&c.repoConn)
+
+	cmd.Flag("repository-list", "optional list of repositories to import").
+		Envar("REPOSITORY_LIST").
+		StringVar(&c.repositoryList)
+
+	cmd.Flag("debug", "enable debug logging").
+		BoolVar(&c.debug)
+}
@@ -16,12 +16,14 @@ package gitimporter
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/harness/harness-migrate/cmd/util"
 	"github.com/harness/harness-migrate/internal/gitimporter"
 	"github.com/harness/harness-migrate/internal/report"
+	"github.com/harness/harness-migrate/internal/users"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/google/uuid"
@@ -50,6 +52,22 @@ type gitImport struct {
 	noLabel     bool
 	noGit       bool // for incremental migration - skip git operations
 	prBatchSize int  // batch size for PR imports to avoid 413 errors
+	noActivity  bool
+
+	internalVisibility string
+	mappingFile        string
+	targetsFile        string
+	onCollision        string
+	attachmentDir      string
+	attachmentURL      string
+	identitiesFile     string
+	mailmapFile        string
+	inviteUsers        bool
+	inviteDomains      []string
+	prAssignees        string
+	prMilestone        string
+	prLinkedIssues     string
+	prMergeOptions     string
 }
 
 type UserInvite bool
@@ -66,6 +84,15 @@ func (c *gitImport) run(*kingpin.ParseContext) error {
 	defer tracer_.Close()
 
 	c.harnessRepo = strings.Trim(c.harnessRepo, "/")
+	if (c.attachmentURL == "") != (c.attachmentDir == "") {
+		return fmt.Errorf("--attachment-url and --attachment-dir must be set together")
+	}
+	if c.inviteUsers && c.skipUsers {
+		return fmt.Errorf("--invite-missing-users and --skip-users cannot be set together")
+	}
+	if c.identitiesFile != "" && c.mailmapFile != "" {
+		return fmt.Errorf("--rewrite-identities and --mailmap cannot be set together")
+	}
 	importUuid := uuid.New().String()
 	c.endpoint, _ = strings.CutSuffix(c.endpoint, "/")
 	reporter := make(map[string]*report.Report)
@@ -80,13 +107,55 @@ func (c *gitImport) run(*kingpin.ParseContext) error {
 			NoRule:        c.noRule,
 			NoLabel:       c.noLabel,
 			NoGit:         c.noGit,
+			NoActivity:    c.noActivity,
 			PRBatchSize:   c.prBatchSize,
+
+			InternalVisibility: c.internalVisibility,
+			OnCollision:        c.onCollision,
+			AttachmentDir:      c.attachmentDir,
+			AttachmentURL:      c.attachmentURL,
+			InviteMissingUsers: c.inviteUsers,
+			InviteDomains:      c.inviteDomains,
+			PRAssignees:        c.prAssignees,
+			PRMilestone:        c.prMilestone,
+			PRLinkedIssues:     c.prLinkedIssues,
+			PRMergeOptions:     c.prMergeOptions,
 		},
 		tracer_,
 		reporter)
 
+	if c.mappingFile != "" {
+		mapping, err := gitimporter.LoadMapping(c.mappingFile)
+		if err != nil {
+			return err
+		}
+		importer.Mapping = mapping
+	}
+
+	if c.identitiesFile != "" {
+		mapping, err := users.LoadMapping(c.identitiesFile)
+		if err != nil {
+			return err
+		}
+		importer.Identities = mapping
+	}
+
+	if c.mailmapFile != "" {
+		mailmap, err := gitimporter.LoadMailmap(c.mailmapFile)
+		if err != nil {
+			return err
+		}
+		importer.Identities = mailmap
+	}
+
 	tracer_.Log("starting operation with id: %s", importUuid)
-	return importer.Import(ctx)
+	importErr := importer.Import(ctx)
+	if c.targetsFile != "" {
+		if err := gitimporter.WriteTargets(c.targetsFile, importer.Targets); err != nil {
+			return err
+		}
+	}
+	return importErr
 }
 
 func registerGitImporter(app *kingpin.CmdClause) {
@@ -118,6 +187,13 @@ func registerGitImporter(app *kingpin.CmdClause) {
 		Envar("harness_SKIP_USERS").
 		BoolVar(&c.skipUsers)
 
+	cmd.Flag("invite-missing-users", "invite unknown users to the account, or create them on Gitness, instead of failing the import").
+		Default("false").
+		BoolVar(&c.inviteUsers)
+
+	cmd.Flag("invite-domain", "email domain of users that can be invited, can be repeated. Default: all domains").
+		StringsVar(&c.inviteDomains)
+
 	cmd.Flag("repo-path", "optional path of a single repo to import (e.g, Org/repo).").
 		Envar("HARNESS_REPO_PATH").
 		StringVar(&c.harnessRepo)
@@ -132,6 +208,50 @@ func registerGitImporter(app *kingpin.CmdClause) {
 		Envar("Gitness").
 		BoolVar(&c.Gitness)
 
+	cmd.Flag("internal-visibility", "visibility of repositories with internal visibility on the source: private or public within the account").
+		Default(gitimporter.VisibilityPrivate).
+		Envar("HARNESS_INTERNAL_VISIBILITY").
+		EnumVar(&c.internalVisibility, gitimporter.VisibilityPrivate, gitimporter.VisibilityPublic)
+
+	cmd.Flag("mapping-file", "optional yaml or csv file mapping source repositories to target spaces and identifiers").
+		Envar("HARNESS_MAPPING_FILE").
+		StringVar(&c.mappingFile)
+
+	cmd.Flag("on-collision", "policy for repositories whose target already exists: skip, suffix or fail").
+		Default(gitimporter.CollisionSkip).
+		EnumVar(&c.onCollision, gitimporter.CollisionSkip, gitimporter.CollisionSuffix, gitimporter.CollisionFail)
+
+	cmd.Flag("targets-file", "optional file to write the resolved target of each repository as json, for git-verify").
+		StringVar(&c.targetsFile)
+
+	cmd.Flag("pr-assignees", "import pull request assignees as reviewers, in the description footer or skip them").
+		Default(gitimporter.MetadataReviewer).
+		EnumVar(&c.prAssignees, gitimporter.MetadataReviewer, gitimporter.MetadataFooter, gitimporter.MetadataSkip)
+
+	cmd.Flag("pr-milestone", "import pull request milestones as label, in the description footer or skip them").
+		Default(gitimporter.MetadataLabel).
+		EnumVar(&c.prMilestone, gitimporter.MetadataLabel, gitimporter.MetadataFooter, gitimporter.MetadataSkip)
+
+	cmd.Flag("pr-linked-issues", "import issues closed by pull requests in the description footer or skip them").
+		Default(gitimporter.MetadataFooter).
+		EnumVar(&c.prLinkedIssues, gitimporter.MetadataFooter, gitimporter.MetadataSkip)
+
+	cmd.Flag("pr-merge-options", "import the squash and auto merge options of pull requests in the description footer or skip them").
+		Default(gitimporter.MetadataFooter).
+		EnumVar(&c.prMergeOptions, gitimporter.MetadataFooter, gitimporter.MetadataSkip)
+
+	cmd.Flag("attachment-dir", "directory attachments are copied to instead of uploading them to the repository").
+		StringVar(&c.attachmentDir)
+
+	cmd.Flag("attachment-url", "url the attachment directory is served from").
+		StringVar(&c.attachmentURL)
+
+	cmd.Flag("rewrite-identities", "rewrite commit author and committer emails with an update-users mapping file. Commit SHAs change").
+		StringVar(&c.identitiesFile)
+
+	cmd.Flag("mailmap", "rewrite commit author and committer identities with a .mailmap file. Commit SHAs change").
+		StringVar(&c.mailmapFile)
+
 	cmd.Flag("no-pr", "").
 		Hidden().
 		Default("false").
@@ -150,6 +270,10 @@ func registerGitImporter(app *kingpin.CmdClause) {
 		Default("false").
 		BoolVar(&c.noLabel)
 
+	cmd.Flag("skip-pr-activity", "skip importing state changes, force pushes and merges of pull requests as comments").
+		Default("false").
+		BoolVar(&c.noActivity)
+
 	cmd.Flag("no-webhook", "").
 		Hidden().
 		Default("false").
@@ -19,4 +19,7 @@ import "github.com/alecthomas/kingpin/v2"
 func Register(app *kingpin.Application) {
 	cmd := app.Command("git-import", "migrate data into harness from exported zip")
 	registerGitImporter(cmd)
+
+	cmd = app.Command("git-verify", "verify imported repositories against the exported zip")
+	registerGitVerify(cmd)
 }
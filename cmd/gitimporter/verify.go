@@ -0,0 +1,246 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/harness/harness-migrate/cmd/util"
+	"github.com/harness/harness-migrate/internal/gitimporter"
+	"github.com/harness/harness-migrate/internal/report"
+	"github.com/harness/harness-migrate/internal/users"
+
+	"github.com/alecthomas/kingpin/v2"
+)
+
+type gitVerify struct {
+	debug      bool
+	trace      bool
+	noProgress bool
+
+	endpoint     string
+	harnessToken string
+	harnessSpace string
+	harnessRepo  string // single repo verification
+
+	Gitness bool
+
+	filePath       string
+	mappingFile    string
+	targetsFile    string
+	onCollision    string
+	identitiesFile string
+	mailmapFile    string
+	output         string
+
+	// optional flags to skip verifying repo meta data
+	noPR       bool
+	noWebhook  bool
+	noRule     bool
+	noLabel    bool
+	noActivity bool
+}
+
+func (c *gitVerify) run(*kingpin.ParseContext) error {
+	// create the logger
+	log := util.CreateLogger(c.debug)
+
+	// attach the logger to the context
+	ctx := context.Background()
+	ctx = util.WithLogger(ctx, log)
+
+	if c.identitiesFile != "" && c.mailmapFile != "" {
+		return fmt.Errorf("--rewrite-identities and --mailmap cannot be set together")
+	}
+
+	tracer_ := util.CreateTracerWithLevelAndType(c.debug, c.noProgress)
+	defer tracer_.Close()
+
+	c.harnessRepo = strings.Trim(c.harnessRepo, "/")
+	c.endpoint, _ = strings.CutSuffix(c.endpoint, "/")
+	importer := gitimporter.NewImporter(
+		c.endpoint, c.harnessSpace, c.harnessRepo, c.harnessToken, c.filePath,
+		"", c.Gitness, c.trace,
+		gitimporter.Flags{
+			NoPR:       c.noPR,
+			NoWebhook:  c.noWebhook,
+			NoRule:     c.noRule,
+			NoLabel:    c.noLabel,
+			NoActivity: c.noActivity,
+
+			OnCollision: c.onCollision,
+		},
+		tracer_,
+		make(map[string]*report.Report))
+
+	if c.mappingFile != "" {
+		mapping, err := gitimporter.LoadMapping(c.mappingFile)
+		if err != nil {
+			return err
+		}
+		importer.Mapping = mapping
+	}
+
+	if c.targetsFile != "" {
+		targets, err := gitimporter.LoadTargets(c.targetsFile)
+		if err != nil {
+			return err
+		}
+		importer.Targets = targets
+	}
+
+	if c.identitiesFile != "" {
+		mapping, err := users.LoadMapping(c.identitiesFile)
+		if err != nil {
+			return err
+		}
+		importer.Identities = mapping
+	}
+
+	if c.mailmapFile != "" {
+		mailmap, err := gitimporter.LoadMailmap(c.mailmapFile)
+		if err != nil {
+			return err
+		}
+		importer.Identities = mailmap
+	}
+
+	results, err := importer.Verify(ctx)
+	if err != nil {
+		return err
+	}
+
+	failed, skipped := 0, 0
+	for _, result := range results {
+		if result.Skipped {
+			skipped++
+			fmt.Printf("SKIP %s\n", result.Source)
+			continue
+		}
+		status := "PASS"
+		if !result.Passed {
+			status = "FAIL"
+			failed++
+		}
+		fmt.Printf("%s %s -> %s\n", status, result.Source, result.Target)
+		for _, diff := range result.Diffs {
+			fmt.Printf("    - %s\n", diff)
+		}
+	}
+	fmt.Printf("%d passed, %d failed, %d skipped\n", len(results)-failed-skipped, failed, skipped)
+
+	if c.output != "" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(c.output, data, 0644); err != nil {
+			return fmt.Errorf("failed to write verification output: %w", err)
+		}
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("verification failed for %d of %d repositories", failed, len(results))
+	}
+	return nil
+}
+
+func registerGitVerify(app *kingpin.CmdClause) {
+	c := new(gitVerify)
+
+	cmd := app.Action(c.run)
+
+	cmd.Arg("filePath", "location of the zip file").
+		Required().
+		StringVar(&c.filePath)
+
+	cmd.Flag("endpoint", "url of target Harness Code/Gitness host").
+		Default("https://app.harness.io/").
+		Envar("target_HOST").
+		StringVar(&c.endpoint)
+
+	cmd.Flag("token", "harness api token").
+		Required().
+		Envar("harness_TOKEN").
+		StringVar(&c.harnessToken)
+
+	cmd.Flag("space", "harness path where the import took place. Example: account/org/project").
+		Required().
+		Envar("harness_SPACE").
+		StringVar(&c.harnessSpace)
+
+	cmd.Flag("repo-path", "optional path of a single repo to verify (e.g, Org/repo).").
+		Envar("HARNESS_REPO_PATH").
+		StringVar(&c.harnessRepo)
+
+	cmd.Flag("gitness", "verify a Gitness instance").
+		Default("false").
+		Envar("Gitness").
+		BoolVar(&c.Gitness)
+
+	cmd.Flag("mapping-file", "optional yaml or csv file mapping source repositories to target spaces and identifiers").
+		Envar("HARNESS_MAPPING_FILE").
+		StringVar(&c.mappingFile)
+
+	cmd.Flag("on-collision", "policy the import resolved existing targets with: skip, suffix or fail").
+		Default(gitimporter.CollisionSkip).
+		EnumVar(&c.onCollision, gitimporter.CollisionSkip, gitimporter.CollisionSuffix, gitimporter.CollisionFail)
+
+	cmd.Flag("targets-file", "optional targets file written by the import with --targets-file").
+		StringVar(&c.targetsFile)
+
+	cmd.Flag("rewrite-identities", "update-users mapping file the commit identities were rewritten with on import").
+		StringVar(&c.identitiesFile)
+
+	cmd.Flag("mailmap", ".mailmap file the commit identities were rewritten with on import").
+		StringVar(&c.mailmapFile)
+
+	cmd.Flag("output", "optional file to write the verification result as json").
+		StringVar(&c.output)
+
+	cmd.Flag("skip-pr", "skip verifying pull requests and comments").
+		Default("false").
+		BoolVar(&c.noPR)
+
+	cmd.Flag("skip-pr-activity", "skip counting pull request activities as comments, if skipped on import").
+		Default("false").
+		BoolVar(&c.noActivity)
+
+	cmd.Flag("skip-label", "skip verifying labels").
+		Default("false").
+		BoolVar(&c.noLabel)
+
+	cmd.Flag("skip-webhook", "skip verifying webhooks").
+		Default("false").
+		BoolVar(&c.noWebhook)
+
+	cmd.Flag("skip-rule", "skip verifying branch protection rules").
+		Default("false").
+		BoolVar(&c.noRule)
+
+	cmd.Flag("debug", "enable debug logging").
+		BoolVar(&c.debug)
+
+	cmd.Flag("trace", "enable trace logging").
+		BoolVar(&c.trace)
+
+	cmd.Flag("no-progress", "disable progress bar logger").
+		Default("false").
+		BoolVar(&c.noProgress)
+}
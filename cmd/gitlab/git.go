@@ -18,6 +18,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httputil"
+	"strconv"
 	"strings"
 
 	"github.com/harness/harness-migrate/cmd/util"
@@ -119,6 +120,10 @@ func (c *exportGitCommand) run(*kingpin.ParseContext) error {
 		NoRule:       c.flags.NoRule,
 		NoLabel:      c.flags.NoLabel,
 		NoLFS:        c.flags.NoLFS,
+		NoAttachment: c.flags.NoAttachment,
+		NoActivity:   c.flags.NoActivity,
+
+		AttachmentSizeLimit: c.flags.AttachmentSizeLimit,
 	}
 
 	e := gitlab.New(client, c.group, repository, checkpointManager, fileLogger, tracer_, reporter, c.includeSubgroups)
@@ -198,6 +203,18 @@ func registerGit(app *kingpin.CmdClause) {
 		Default("false").
 		BoolVar(&c.flags.NoLFS)
 
+	cmd.Flag("no-attachment", "do NOT export attachments of pull requests and comments").
+		Default("false").
+		BoolVar(&c.flags.NoAttachment)
+
+	cmd.Flag("no-pr-activity", "do NOT export state changes, force pushes and merges of pull requests").
+		Default("false").
+		BoolVar(&c.flags.NoActivity)
+
+	cmd.Flag("attachment-size-limit", "max size of an exported attachment in bytes. Default: 10MB").
+		Default(strconv.FormatInt(gitexporter.DefaultAttachmentSizeLimit, 10)).
+		Int64Var(&c.flags.AttachmentSizeLimit)
+
 	cmd.Flag("debug", "enable debug logging").
 		BoolVar(&c.debug)
 
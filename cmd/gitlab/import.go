@@ -17,6 +17,7 @@ package gitlab
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"os"
 
@@ -42,6 +43,9 @@ type importCommand struct {
 
 	gitlabToken    string
 	gitlabEndpoint string
+
+	// import into gitness
+	gitness bool
 }
 
 func (c *importCommand) run(*kingpin.ParseContext) error {
@@ -51,6 +55,10 @@ func (c *importCommand) run(*kingpin.ParseContext) error {
 	ctx := context.Background()
 	ctx = util.WithLogger(ctx, logger)
 
+	if c.harnessAccount == "" && !c.gitness {
+		return errors.New("harness account is required")
+	}
+
 	// read the data file
 	data, err := os.ReadFile(c.file)
 	if err != nil {
@@ -99,6 +107,13 @@ func (c *importCommand) run(*kingpin.ParseContext) error {
 		Tracer:       tracer_,
 	}
 
+	// gitness spaces replace the organization and projects.
+	if c.gitness {
+		importer.Harness = harness.NewGitness(c.harnessToken, c.harnessEndpoint,
+			harness.WithTracing(c.debug))
+		importer.Gitness = true
+	}
+
 	// // execute the import routine.
 	return importer.Import(ctx, org)
 }
@@ -114,8 +129,7 @@ func registerImport(app *kingpin.CmdClause) {
 	cmd.Arg("file", "data file to import").
 		StringVar(&c.file)
 
-	cmd.Flag("harness-account", "harness account").
-		Required().
+	cmd.Flag("harness-account", "harness account, not required for gitness").
 		Envar("HARNESS_ACCOUNT").
 		StringVar(&c.harnessAccount)
 
@@ -141,6 +155,10 @@ func registerImport(app *kingpin.CmdClause) {
 		Envar("GITLAB_ENDPONT").
 		StringVar(&c.gitlabEndpoint)
 
+	cmd.Flag("gitness", "import into a Gitness instance at the harness endpoint").
+		Envar("GITNESS").
+		BoolVar(&c.gitness)
+
 	cmd.Flag("debug", "enable debug logging").
 		BoolVar(&c.debug)
 }
@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkinsxml
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/harness/harness-migrate/cmd/util"
+	"github.com/harness/harness-migrate/internal/migrate/jenkins"
+	"github.com/harness/harness-migrate/internal/migrate/jenkins/client"
+	"github.com/harness/harness-migrate/internal/tracer"
+
+	"github.com/alecthomas/kingpin/v2"
+)
+
+type exportCommand struct {
+	debug bool
+	trace bool
+	file  string
+
+	address  string
+	username string
+	password string
+	folder   string
+	org      string
+
+	kubeName   string
+	kubeConn   string
+	dockerConn string
+}
+
+func (c *exportCommand) run(*kingpin.ParseContext) error {
+
+	// create the logger
+	log := util.CreateLogger(c.debug)
+
+	// attach the logger to the context
+	ctx := context.Background()
+	ctx = util.WithLogger(ctx, log)
+
+	// create the jenkins client
+	client := client.New(c.address,
+		client.WithCredentials(c.username, c.password),
+		client.WithTracing(c.trace),
+	)
+
+	// create the tracer
+	tracer_ := tracer.New()
+	defer tracer_.Close()
+
+	// extract the data
+	exporter := jenkins.Exporter{
+		Jenkins:    client,
+		Folder:     c.folder,
+		Org:        c.org,
+		DockerConn: c.dockerConn,
+		KubeName:   c.kubeName,
+		KubeConn:   c.kubeConn,
+		Tracer:     tracer_,
+	}
+	data, err := exporter.Export(ctx)
+	if err != nil {
+		log.Error("Failed to extract data: ", err)
+		return err
+	}
+
+	// if no file path is provided, write the data export
+	// to stdout.
+	if c.file == "" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	}
+
+	// else write the data export to the file.
+	file, err := json.MarshalIndent(data, "", " ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.file, file, 0644)
+}
+
+// helper function registers the export command
+func registerExport(app *kingpin.CmdClause) {
+	c := new(exportCommand)
+
+	cmd := app.Command("export", "export and convert all jobs of a jenkins server").
+		Action(c.run)
+
+	cmd.Arg("save", "save the output to a file").
+		StringVar(&c.file)
+
+	cmd.Flag("jenkins-address", "jenkins server address").
+		Required().
+		Envar("JENKINS_ADDRESS").
+		StringVar(&c.address)
+
+	cmd.Flag("jenkins-username", "jenkins username").
+		Envar("JENKINS_USERNAME").
+		StringVar(&c.username)
+
+	cmd.Flag("jenkins-password", "jenkins password or api token").
+		Envar("JENKINS_PASSWORD").
+		StringVar(&c.password)
+
+	cmd.Flag("folder", "optional jenkins folder to export").
+		Envar("JENKINS_FOLDER").
+		StringVar(&c.folder)
+
+	cmd.Flag("org", "name of the exported organization").
+		Default("jenkins").
+		StringVar(&c.org)
+
+	cmd.Flag("kube-connector", "kubernetes connector").
+		StringVar(&c.kubeConn)
+
+	cmd.Flag("kube-namespace", "kubernetes namespace").
+		StringVar(&c.kubeName)
+
+	cmd.Flag("docker-connector", "dockerhub connector").
+		StringVar(&c.dockerConn)
+
+	cmd.Flag("debug", "enable debug logging").
+		BoolVar(&c.debug)
+
+	cmd.Flag("trace", "enable trace logging").
+		BoolVar(&c.trace)
+}
@@ -19,4 +19,5 @@ import "github.com/alecthomas/kingpin/v2"
 func Register(app *kingpin.Application) {
 	cmd := app.Command("jenkinsxml", "migrate jenkins xml job data")
 	registerConvert(cmd)
+	registerExport(cmd)
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/alecthomas/kingpin/v2"
@@ -107,6 +108,10 @@ func (c *exportCommand) run(*kingpin.ParseContext) error {
 		NoRule:       c.flags.NoRule,
 		NoLabel:      true, // stash doesnt support labels
 		NoLFS:        c.flags.NoLFS,
+		NoAttachment: c.flags.NoAttachment,
+		NoActivity:   c.flags.NoActivity,
+
+		AttachmentSizeLimit: c.flags.AttachmentSizeLimit,
 	}
 	// extract the data
 	e := stash.New(client, c.project, repository, checkpointManager, fileLogger, tracer_, reporter)
@@ -179,6 +184,18 @@ func registerGit(app *kingpin.CmdClause) {
 		Default("false").
 		BoolVar(&c.flags.NoLFS)
 
+	cmd.Flag("no-attachment", "do NOT export attachments of pull requests and comments").
+		Default("false").
+		BoolVar(&c.flags.NoAttachment)
+
+	cmd.Flag("no-pr-activity", "do NOT export state changes, force pushes and merges of pull requests").
+		Default("false").
+		BoolVar(&c.flags.NoActivity)
+
+	cmd.Flag("attachment-size-limit", "max size of an exported attachment in bytes. Default: 10MB").
+		Default(strconv.FormatInt(gitexporter.DefaultAttachmentSizeLimit, 10)).
+		Int64Var(&c.flags.AttachmentSizeLimit)
+
 	cmd.Flag("debug", "enable debug logging").
 		BoolVar(&c.debug)
 
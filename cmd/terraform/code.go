@@ -0,0 +1,230 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terraform
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/harness/harness-migrate/internal/gitimporter"
+	"github.com/harness/harness-migrate/internal/util"
+	"github.com/harness/harness-migrate/types"
+
+	"github.com/drone/funcmap"
+
+	_ "embed"
+)
+
+//go:embed code.tmpl
+var codeTmpl string
+
+// reInvalidName matches characters that are not allowed
+// in terraform resource names.
+var reInvalidName = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// runCode generates terraform for the code repositories, branch
+// rules, webhooks and labels of a git export archive.
+func (c *terraformCommand) runCode() error {
+	dir, err := os.MkdirTemp("", "harness-terraform-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := util.Unzip(c.input, dir); err != nil {
+		return fmt.Errorf("cannot unzip %s: %w", c.input, err)
+	}
+
+	repos, err := gitimporter.ReadArchive(dir)
+	if err != nil {
+		return err
+	}
+
+	tmpl := codeTmpl
+	if c.tmpl != "" {
+		t, err := ioutil.ReadFile(c.tmpl)
+		if err != nil {
+			return err
+		}
+		tmpl = string(t)
+	}
+
+	t, err := c.parseCodeTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+
+	in := c.createCodeInput(repos)
+	buf, err := c.generateTerraformFile(t, in)
+	if err != nil {
+		return err
+	}
+	return c.writeTerraformFile(buf, c.output)
+}
+
+func (c *terraformCommand) createCodeInput(repos []*types.RepositoryData) *codeInput {
+	in := &codeInput{
+		Auth: auth{
+			Endpoint: c.endpoint,
+		},
+		Account: account{
+			ID:           c.account,
+			Organization: c.organization,
+		},
+		Provider: provider{
+			Source:  c.providerSource,
+			Version: c.providerVersion,
+		},
+		Project: c.project,
+	}
+	emails := map[string]bool{}
+	for _, data := range repos {
+		// the slug includes the namespace, so repositories with
+		// the same name in different namespaces do not collide.
+		repo := &codeRepo{
+			Name:       resourceName(data.Repository.Slug),
+			Identifier: data.Repository.Name,
+			Repo:       data.Repository,
+		}
+		for _, rule := range data.BranchRules {
+			for _, email := range rule.Definition.Bypass.UserEmails {
+				if !emails[email] {
+					emails[email] = true
+					in.BypassEmails = append(in.BypassEmails, email)
+				}
+			}
+			repo.Rules = append(repo.Rules, &codeRule{
+				Name: resourceName(rule.Identifier),
+				Rule: rule,
+			})
+		}
+		for _, hook := range data.Webhooks.Hooks {
+			repo.Hooks = append(repo.Hooks, &codeHook{
+				Name: resourceName(hook.Identifier),
+				Hook: hook,
+			})
+		}
+		repo.Labels = groupLabels(data.Labels)
+		in.Repos = append(in.Repos, repo)
+	}
+	sort.Strings(in.BypassEmails)
+	return in
+}
+
+func (c *terraformCommand) parseCodeTemplate(tmpl string) (*template.Template, error) {
+	return template.New("_").
+		Funcs(funcmap.Funcs).
+		Funcs(template.FuncMap{
+			"quote": quote,
+			"list": func(in []string) string {
+				out := make([]string, len(in))
+				for i, s := range in {
+					out[i] = quote(s)
+				}
+				return "[" + strings.Join(out, ", ") + "]"
+			},
+		}).
+		Parse(tmpl)
+}
+
+// helper function groups the exported label values by
+// label name, preserving the order of first appearance.
+func groupLabels(labels []types.Label) []*codeLabel {
+	var out []*codeLabel
+	index := map[string]*codeLabel{}
+	for _, label := range labels {
+		l, ok := index[label.Name]
+		if !ok {
+			l = &codeLabel{
+				Name:  resourceName(label.Name),
+				Label: label,
+			}
+			index[label.Name] = l
+			out = append(out, l)
+		}
+		if label.Value != "" {
+			l.Values = append(l.Values, label.Value)
+		}
+	}
+	for _, l := range out {
+		sort.Strings(l.Values)
+	}
+	return out
+}
+
+// helper function quotes the string as a terraform string
+// literal, escaping the template sequences ${ and %{.
+func quote(s string) string {
+	s = strconv.Quote(s)
+	s = strings.ReplaceAll(s, "${", "$${")
+	return strings.ReplaceAll(s, "%{", "%%{")
+}
+
+// helper function converts the name to a valid terraform
+// resource name.
+func resourceName(name string) string {
+	name = reInvalidName.ReplaceAllString(strings.ToLower(name), "_")
+	name = strings.Trim(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+type (
+	codeInput struct {
+		Account  account
+		Auth     auth
+		Provider provider
+		Project  string
+		Repos    []*codeRepo
+
+		// BypassEmails holds the emails of the branch rule
+		// bypass users, which are mapped to user ids with
+		// a variable.
+		BypassEmails []string
+	}
+
+	codeRepo struct {
+		Name       string
+		Identifier string
+		Repo       types.Repository
+		Rules      []*codeRule
+		Hooks      []*codeHook
+		Labels     []*codeLabel
+	}
+
+	codeRule struct {
+		Name string
+		Rule *types.BranchRule
+	}
+
+	codeHook struct {
+		Name string
+		Hook *types.Hook
+	}
+
+	codeLabel struct {
+		Name   string
+		Label  types.Label
+		Values []string
+	}
+)
@@ -17,6 +17,7 @@ package terraform
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -26,8 +27,10 @@ import (
 	"github.com/drone/funcmap"
 	"github.com/drone/go-convert/convert/drone"
 	"github.com/drone/go-convert/convert/harness/downgrader"
+	"github.com/harness/harness-migrate/internal/migrate/jenkins"
 	"github.com/harness/harness-migrate/internal/slug"
 	"github.com/harness/harness-migrate/internal/types"
+	"github.com/harness/harness-migrate/internal/util"
 
 	"github.com/alecthomas/chroma/quick"
 	"github.com/alecthomas/kingpin/v2"
@@ -48,6 +51,7 @@ type terraformCommand struct {
 	account         string
 	endpoint        string
 	organization    string
+	project         string
 	providerSource  string
 	providerVersion string
 	repoConn        string
@@ -58,11 +62,23 @@ type terraformCommand struct {
 	downgrade  bool
 	orgSecrets bool
 
+	secretMode    string
+	secretManager string
+	secretMount   string
+	secretPath    string
+	secretsFile   string
+
 	color bool
 	theme string
 }
 
 func (c *terraformCommand) run(ctx *kingpin.ParseContext) error {
+	// a git export archive describes code repositories
+	// rather than pipelines.
+	if strings.HasSuffix(c.input, ".zip") {
+		return c.runCode()
+	}
+
 	org, err := c.readAndUnmarshal(c.input)
 	if err != nil {
 		return err
@@ -70,6 +86,15 @@ func (c *terraformCommand) run(ctx *kingpin.ParseContext) error {
 
 	in := c.createTemplateInput(org)
 
+	in.Repos, err = c.parseRepos(org)
+	if err != nil {
+		return err
+	}
+
+	if c.secretMode == secretModeHarness && c.secretManager == "" {
+		return errors.New("secret manager is required to reference harness secrets")
+	}
+
 	if err := c.convertYaml(org); err != nil {
 		return err
 	}
@@ -93,7 +118,13 @@ func (c *terraformCommand) run(ctx *kingpin.ParseContext) error {
 		return err
 	}
 
-	return c.writeTerraformFile(buf, c.output)
+	if err := c.writeTerraformFile(buf, c.output); err != nil {
+		return err
+	}
+	if !in.HasSecrets {
+		return nil
+	}
+	return c.writeSecretsFile(org)
 }
 
 func (c *terraformCommand) readAndUnmarshal(input string) (*types.Org, error) {
@@ -128,7 +159,123 @@ func (c *terraformCommand) createTemplateInput(org *types.Org) input {
 		Selections: selections{
 			OrgSecrets: c.orgSecrets,
 		},
+		Secrets: secrets{
+			Mode:    c.secretMode,
+			Manager: c.secretManager,
+			Mount:   c.secretMount,
+			Prefix:  secretPrefix(c.secretPath),
+		},
+		HasSecrets: c.hasSecrets(org),
+	}
+}
+
+// parseRepos parses the repository url of each project into
+// the host, namespace and repository name, keyed by project name.
+// Projects without a repository, such as jenkins jobs without an
+// scm, are skipped since a pipeline cannot be created for them.
+func (c *terraformCommand) parseRepos(org *types.Org) (map[string]*util.RepoHost, error) {
+	repos := map[string]*util.RepoHost{}
+	var projects []*types.Project
+	for _, project := range org.Projects {
+		if project.Repo == "" {
+			continue
+		}
+		projects = append(projects, project)
+		repo, err := util.ParseRepoURL(project.Repo)
+		if err != nil {
+			return nil, fmt.Errorf("project %s: %w", project.Name, err)
+		}
+		repos[project.Name] = repo
+	}
+	org.Projects = projects
+	return repos, nil
+}
+
+func (c *terraformCommand) hasSecrets(org *types.Org) bool {
+	if c.orgSecrets && len(org.Secrets) > 0 {
+		return true
+	}
+	for _, project := range org.Projects {
+		if len(project.Secrets) > 0 {
+			return true
+		}
 	}
+	return false
+}
+
+// writeSecretsFile writes the secret values, which are never part
+// of the terraform file. The values are written as a tfvars file
+// when referenced as variables, otherwise keyed by the secret store
+// path to seed the store.
+func (c *terraformCommand) writeSecretsFile(org *types.Org) error {
+	var out interface{}
+	if c.secretMode == secretModeVariable {
+		values := map[string]string{}
+		if c.orgSecrets {
+			for _, secret := range org.Secrets {
+				values[secret.Name] = secret.Value
+			}
+		}
+		for _, project := range org.Projects {
+			for _, secret := range project.Secrets {
+				values[project.Name+"/"+secret.Name] = secret.Value
+			}
+		}
+		out = map[string]interface{}{"secrets": values}
+	} else {
+		prefix := secretPrefix(c.secretPath)
+		paths := map[string]map[string]string{}
+		if c.orgSecrets && len(org.Secrets) > 0 {
+			values := map[string]string{}
+			for _, secret := range org.Secrets {
+				values[secret.Name] = secret.Value
+			}
+			paths[prefix+c.organization] = values
+		}
+		for _, project := range org.Projects {
+			if len(project.Secrets) == 0 {
+				continue
+			}
+			values := map[string]string{}
+			for _, secret := range project.Secrets {
+				values[secret.Name] = secret.Value
+			}
+			paths[prefix+c.organization+"/"+project.Name] = values
+		}
+		out = paths
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.secretsFilePath(), append(data, '\n'), 0600)
+}
+
+// secretsFilePath returns the secrets file path, which defaults
+// to a file next to the terraform file.
+func (c *terraformCommand) secretsFilePath() string {
+	if c.secretsFile != "" {
+		return c.secretsFile
+	}
+	base := "secrets"
+	if c.output != "" && c.output != "-" {
+		base = strings.TrimSuffix(c.output, ".tf")
+	}
+	if c.secretMode == secretModeVariable {
+		return base + ".auto.tfvars.json"
+	}
+	return base + ".secrets.json"
+}
+
+// helper function returns the secret path with a trailing
+// separator, or empty if no path is configured.
+func secretPrefix(path string) string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return ""
+	}
+	return path + "/"
 }
 
 func (c *terraformCommand) convertYaml(org *types.Org) error {
@@ -145,10 +292,16 @@ func (c *terraformCommand) convertYaml(org *types.Org) error {
 	)
 
 	for _, project := range org.Projects {
-		// convert to v1
-		convertedYaml, err := converter.ConvertBytes(project.Yaml)
-		if err != nil {
-			return err
+		var err error
+		convertedYaml := project.Yaml
+		// jenkins projects are converted to the harness yaml
+		// format during export.
+		if project.Type != jenkins.ProjectType {
+			// convert to v1
+			convertedYaml, err = converter.ConvertBytes(project.Yaml)
+			if err != nil {
+				return err
+			}
 		}
 		// downgrade to v0 if needed
 		if c.downgrade {
@@ -198,7 +351,7 @@ func (c *terraformCommand) parseTemplate(tmpl string) (*template.Template, error
 		Parse(tmpl)
 }
 
-func (c *terraformCommand) generateTerraformFile(t *template.Template, in *input) (bytes.Buffer, error) {
+func (c *terraformCommand) generateTerraformFile(t *template.Template, in interface{}) (bytes.Buffer, error) {
 	var buf bytes.Buffer
 	if err := t.Execute(&buf, in); err != nil {
 		return buf, err
@@ -230,7 +383,7 @@ func Register(app *kingpin.Application) {
 	cmd := app.Command("terraform", "generate terraform script from data export file").
 		Action(c.run)
 
-	cmd.Arg("input", "path to the data export file").
+	cmd.Arg("input", "path to the data export file or git export zip").
 		Default("export.json").
 		StringVar(&c.input)
 
@@ -258,6 +411,10 @@ func Register(app *kingpin.Application) {
 		Default("default").
 		StringVar(&c.organization)
 
+	cmd.Flag("project", "harness project of the code repositories").
+		Default("default").
+		StringVar(&c.project)
+
 	cmd.Flag("color", "print with syntax highlighting").
 		Envar("COLOR").
 		Default(fmt.Sprint(tty)).
@@ -295,6 +452,24 @@ func Register(app *kingpin.Application) {
 	cmd.Flag("org-secrets", "generate organization secrets").
 		Default("true").
 		BoolVar(&c.orgSecrets)
+
+	cmd.Flag("secret-mode", "how secret values are referenced: variable, vault or harness").
+		Default(secretModeVariable).
+		EnumVar(&c.secretMode, secretModeVariable, secretModeVault, secretModeHarness)
+
+	cmd.Flag("secret-manager", "harness secret manager identifier used to reference secrets").
+		StringVar(&c.secretManager)
+
+	cmd.Flag("secret-mount", "vault kv v2 mount used to read secrets").
+		Default("secret").
+		StringVar(&c.secretMount)
+
+	cmd.Flag("secret-path", "path prefix of the secrets in vault or the secret manager").
+		Default("harness").
+		StringVar(&c.secretPath)
+
+	cmd.Flag("secrets-file", "path to save the secret values, never commit this file").
+		StringVar(&c.secretsFile)
 }
 
 type (
@@ -305,6 +480,11 @@ type (
 		Org        *types.Org
 		Provider   provider
 		Selections selections
+		Secrets    secrets
+		HasSecrets bool
+		// Repos holds the parsed repository of each
+		// project, keyed by project name.
+		Repos map[string]*util.RepoHost
 	}
 
 	account struct {
@@ -329,4 +509,19 @@ type (
 	selections struct {
 		OrgSecrets bool
 	}
+
+	secrets struct {
+		Mode    string
+		Manager string
+		Mount   string
+		Prefix  string
+	}
+)
+
+// Secret modes define how the generated configuration
+// references secret values.
+const (
+	secretModeVariable = "variable"
+	secretModeVault    = "vault"
+	secretModeHarness  = "harness"
 )
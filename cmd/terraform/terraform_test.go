@@ -5,6 +5,9 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/harness/harness-migrate/internal/types"
+	"github.com/harness/harness-migrate/internal/util"
+
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -36,6 +39,7 @@ func TestRun(t *testing.T) {
 					dockerConn:      "exampleDockerConn",
 					downgrade:       true,
 					orgSecrets:      true,
+					secretMode:      secretModeVariable,
 				}
 
 				kubernetesTerraformCommand := terraformCommand{
@@ -51,6 +55,7 @@ func TestRun(t *testing.T) {
 					kubeConn:        "exampleKubeConn",
 					downgrade:       true,
 					orgSecrets:      true,
+					secretMode:      secretModeVariable,
 				}
 
 				if pipelineType == "docker" {
@@ -90,3 +95,170 @@ func TestRun(t *testing.T) {
 		}
 	}
 }
+
+func TestSecretModes(t *testing.T) {
+	tests := []struct {
+		mode    string
+		manager string
+	}{
+		{mode: secretModeVault},
+		{mode: secretModeHarness, manager: "vault"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.mode, func(t *testing.T) {
+			dir := t.TempDir()
+			c := terraformCommand{
+				input:           "testdata/examples/docker/example-1.json",
+				account:         "DNVIhrzCr9SnPHMQUEvRspB",
+				endpoint:        "https://app.harness.io/gateway",
+				providerSource:  "harness/harness",
+				providerVersion: "0.19.1",
+				output:          filepath.Join(dir, "main.tf"),
+				organization:    "exampleOrg",
+				dockerConn:      "exampleDockerConn",
+				downgrade:       true,
+				orgSecrets:      true,
+				secretMode:      test.mode,
+				secretManager:   test.manager,
+				secretMount:     "secret",
+				secretPath:      "harness",
+			}
+			if err := c.run(nil); err != nil {
+				t.Error(err)
+				return
+			}
+
+			for _, file := range []string{"main.tf", "main.secrets.json"} {
+				got, err := os.ReadFile(filepath.Join(dir, file))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				want, err := os.ReadFile("testdata/secrets/" + test.mode + "." + file + ".golden")
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if diff := cmp.Diff(got, want); diff != "" {
+					t.Errorf("Unexpected %s for secret mode %s", file, test.mode)
+					t.Log(diff)
+				}
+			}
+		})
+	}
+}
+
+func TestSecretModeHarnessRequiresManager(t *testing.T) {
+	c := terraformCommand{
+		input:      "testdata/examples/docker/example-1.json",
+		output:     filepath.Join(t.TempDir(), "main.tf"),
+		downgrade:  true,
+		secretMode: secretModeHarness,
+	}
+	if err := c.run(nil); err == nil {
+		t.Errorf("Want error without secret manager")
+	}
+}
+
+func TestParseRepos(t *testing.T) {
+	org := &types.Org{
+		Projects: []*types.Project{
+			{Name: "subgroup", Repo: "https://gitlab.com/group/sub/project.git"},
+			{Name: "server", Repo: "https://stash.company.com/scm/PROJ/repo.git"},
+			{Name: "ssh", Repo: "git@github.com:octocat/hello-world.git"},
+			{Name: "noscm"},
+		},
+	}
+	repos, err := new(terraformCommand).parseRepos(org)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if _, ok := repos["noscm"]; ok || len(org.Projects) != 3 {
+		t.Errorf("Want project without repository skipped")
+	}
+	want := map[string][2]string{
+		"subgroup": {"group/sub", "project"},
+		"server":   {"PROJ", "repo"},
+		"ssh":      {"octocat", "hello-world"},
+	}
+	for name, w := range want {
+		if got := repos[name]; got.Namespace != w[0] || got.Name != w[1] {
+			t.Errorf("Want %s/%s for project %s, got %s/%s", w[0], w[1], name, got.Namespace, got.Name)
+		}
+	}
+
+	org.Projects = append(org.Projects, &types.Project{Name: "invalid", Repo: "hello-world"})
+	if _, err := new(terraformCommand).parseRepos(org); err == nil {
+		t.Errorf("Want error for invalid repository url")
+	}
+}
+
+func TestCode(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "harness.zip")
+	if err := util.ZipFolder("testdata/code", input); err != nil {
+		t.Error(err)
+		return
+	}
+
+	c := terraformCommand{
+		input:           input,
+		account:         "DNVIhrzCr9SnPHMQUEvRspB",
+		endpoint:        "https://app.harness.io/gateway",
+		providerSource:  "harness/harness",
+		providerVersion: "0.19.1",
+		output:          filepath.Join(dir, "main.tf"),
+		organization:    "exampleOrg",
+		project:         "exampleProject",
+	}
+	if err := c.run(nil); err != nil {
+		t.Error(err)
+		return
+	}
+
+	got, err := os.ReadFile(c.output)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	want, err := os.ReadFile("testdata/code/main.tf.golden")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected terraform for code repositories")
+		t.Log(diff)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := map[string]string{
+		`say "hi"`:       `"say \"hi\""`,
+		"${var.secret}":  `"$${var.secret}"`,
+		"%{ if true }":   `"%%{ if true }"`,
+		"$HOME and 100%": `"$HOME and 100%"`,
+	}
+	for in, want := range tests {
+		if got := quote(in); got != want {
+			t.Errorf("Want quoted string %s for %q, got %s", want, in, got)
+		}
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	tests := map[string]string{
+		"hello-world":  "hello_world",
+		"Release/1.x":  "release_1_x",
+		"1-ci":         "_1_ci",
+		"--":           "_",
+		"protect_main": "protect_main",
+	}
+	for in, want := range tests {
+		if got := resourceName(in); got != want {
+			t.Errorf("Want resource name %q for %q, got %q", want, in, got)
+		}
+	}
+}
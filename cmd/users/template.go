@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package users
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/harness/harness-migrate/cmd/util"
+	"github.com/harness/harness-migrate/internal/gitexporter"
+	"github.com/harness/harness-migrate/internal/harness"
+	"github.com/harness/harness-migrate/internal/users"
+
+	"github.com/alecthomas/kingpin/v2"
+)
+
+type templateCommand struct {
+	zipFilePath string
+	output      string
+	check       bool
+	endpoint    string
+	token       string
+	space       string
+	gitness     bool
+	debug       bool
+	noProgress  bool
+}
+
+// run executes the users template command
+func (c *templateCommand) run(*kingpin.ParseContext) error {
+	// create the logger
+	log := util.CreateLogger(c.debug)
+
+	// attach the logger to the context
+	ctx := context.Background()
+	ctx = util.WithLogger(ctx, log)
+
+	tracer := util.CreateTracerWithLevelAndType(c.debug, c.noProgress)
+	defer tracer.Close()
+
+	var client harness.Client
+	if c.check {
+		if c.token == "" || (c.space == "" && !c.gitness) {
+			return errors.New("--check requires --token and --space")
+		}
+		endpoint, _ := strings.CutSuffix(c.endpoint, "/")
+		account, _, _ := strings.Cut(strings.Trim(c.space, "/"), "/")
+		client = harness.New(account, c.token, harness.WithAddress(endpoint))
+		if c.gitness {
+			client = harness.NewGitness(c.token, endpoint)
+		}
+	}
+
+	template := users.NewTemplate(
+		c.zipFilePath,
+		c.output,
+		client,
+		tracer,
+	)
+
+	return template.Generate(ctx)
+}
+
+func registerTemplate(app *kingpin.CmdClause) {
+	c := new(templateCommand)
+
+	cmd := app.Action(c.run)
+
+	cmd.Arg("zipFilePath", "path to the exported zip file containing SCM data").
+		Default(filepath.Join("harness", gitexporter.ZipFileName)).
+		StringVar(&c.zipFilePath)
+
+	cmd.Flag("output", "path of the user mapping template, written as csv or json by file extension").
+		Default("users.csv").
+		StringVar(&c.output)
+
+	cmd.Flag("check", "check which users already exist in Harness").
+		Default("false").
+		BoolVar(&c.check)
+
+	cmd.Flag("endpoint", "url of target Harness Code/Gitness host").
+		Default("https://app.harness.io/").
+		Envar("target_HOST").
+		StringVar(&c.endpoint)
+
+	cmd.Flag("token", "harness api token").
+		Envar("harness_TOKEN").
+		StringVar(&c.token)
+
+	cmd.Flag("space", "harness path of the import, the account is used to check users. Example: account/org/project").
+		Envar("harness_SPACE").
+		StringVar(&c.space)
+
+	cmd.Flag("gitness", "check users on a Gitness instance").
+		Default("false").
+		Envar("Gitness").
+		BoolVar(&c.gitness)
+
+	cmd.Flag("debug", "enable debug logging").
+		BoolVar(&c.debug)
+
+	cmd.Flag("no-progress", "disable progress bar logger").
+		Default("false").
+		BoolVar(&c.noProgress)
+}
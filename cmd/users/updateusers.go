@@ -16,6 +16,7 @@ package users
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 
 	"github.com/harness/harness-migrate/cmd/util"
@@ -28,6 +29,7 @@ import (
 type updateUserCommand struct {
 	usermapping string
 	zipFilePath string
+	dryRun      bool
 	debug       bool
 	noProgress  bool
 }
@@ -49,10 +51,21 @@ func (c *updateUserCommand) run(*kingpin.ParseContext) error {
 	updater := users.NewUpdater(
 		c.usermapping,
 		c.zipFilePath,
+		c.dryRun,
 		tracer,
 	)
 
-	return updater.Update(ctx)
+	if err := updater.Update(ctx); err != nil {
+		return err
+	}
+
+	if c.dryRun {
+		for _, rewrite := range updater.Rewrites() {
+			fmt.Printf("%s %s: %s -> %s\n", rewrite.Repository, rewrite.Location, rewrite.From, rewrite.To)
+		}
+		fmt.Printf("%d emails would be updated\n", len(updater.Rewrites()))
+	}
+	return nil
 }
 
 func registerUpdateUsers(app *kingpin.CmdClause) {
@@ -60,7 +73,7 @@ func registerUpdateUsers(app *kingpin.CmdClause) {
 
 	cmd := app.Action(c.run)
 
-	cmd.Arg("users", "path to the JSON file containing user email mappings").
+	cmd.Arg("users", "path to the user mapping file: a JSON map of emails, a users template, or a yaml/JSON file with mapping rules").
 		Required().
 		StringVar(&c.usermapping)
 
@@ -68,6 +81,10 @@ func registerUpdateUsers(app *kingpin.CmdClause) {
 		Default(filepath.Join("harness", gitexporter.ZipFileName)).
 		StringVar(&c.zipFilePath)
 
+	cmd.Flag("dry-run", "list the emails that would be updated without updating the zip file").
+		Default("false").
+		BoolVar(&c.dryRun)
+
 	cmd.Flag("debug", "enable debug logging").
 		BoolVar(&c.debug)
 
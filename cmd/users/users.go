@@ -19,4 +19,7 @@ import "github.com/alecthomas/kingpin/v2"
 func Register(app *kingpin.Application) {
 	cmd := app.Command("update-users", "update users email with the provided user mapping in the exported zip file")
 	registerUpdateUsers(cmd)
+
+	users := app.Command("users", "manage users of the exported zip file")
+	registerTemplate(users.Command("template", "generate a user mapping template from the exported zip file"))
 }
@@ -10,6 +10,7 @@ const (
 	PRCommentCheckpointData   = "%s/%d/comment/data"
 	PRReviewerCheckpointPage  = "%s/%d/reviewer"
 	PRReviewerCheckpointData  = "%s/%d/reviewer/data"
+	PRActivityCheckpointData  = "%s/%d/activity/data"
 	LabelCheckpointPage       = "%s/labels"
 	LabelCheckpointData       = "%s/labels/data"
 	WebhookCheckpointPage     = "%s/webhook"
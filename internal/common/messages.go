@@ -32,6 +32,9 @@ const (
 	MsgCompleteExportPrComments  = "Finished export %d comments for repository %s pull request number %d."
 	MsgStartExportPrReviewers    = "Starting export reviewers for repository %s pull request number %d."
 	MsgCompleteExportPrReviewers = "Finished export %d reviewers for repository %s pull request number %d."
+	MsgStartExportPrActivities   = "Starting export activities for repository %s pull request number %d."
+	MsgCompleteExportPrActivities = "Finished export %d activities for repository %s pull request number %d."
+	MsgCheckpointLoadPRActivities = "Finished export %d activities for repository %s pull request number %d from checkpoint."
 	MsgStartCommentsFetch        = "Starting fetching comments for PRs in repo %s"
 	MsgCompleteCommentsFetch     = "Finished fetching comments for PRs in repo %s"
 	MsgCheckpointLoadPRComments  = "Finished export %d comments for repository %s pull request number %d from checkpoint."
@@ -41,8 +44,14 @@ const (
 	MsgCompleteExportBranchRules = "Finished export %d branch rules for repository %s."
 	MsgStartExportLabels         = "Starting export labels for repository %s."
 	MsgCompleteExportLabels      = "Finished export %d labels for repository %s."
+	MsgStartExportSpaceLabels    = "Starting export labels for space %s."
+	MsgCompleteExportSpaceLabels = "Finished export %d labels for space %s."
 	MsgStartRepoLFSEnabled       = "Starting check Git LFS is enabled for repository %s."
 	MsgCompleteRepoLFSEnabled    = "Finished check Git LFS is enabled for repository %s."
+	MsgStartRepoSettings         = "Starting export settings for repository %s."
+	MsgCompleteRepoSettings      = "Finished export settings for repository %s."
+	MsgStartExportAttachments    = "Starting export attachments for repository %s."
+	MsgCompleteExportAttachments = "Finished export %d attachments for repository %s."
 
 	MsgStartImportFromFolders    = "Starting import repositories from folders: %v"
 	MsgCompleteImport            = "Finished import repositories. Total repos: %d."
@@ -51,11 +60,23 @@ const (
 	MsgStartUpdateRepoSetting    = "Starting update repository setting for %s, push size limit to %d, Git LFS enabled to %v"
 	MsgCompleteUpdateRepoSetting = "Finished update repository setting for %s, push size limit is %d, Git LFS enabled is %v."
 	MsgStartImportCreateRepo     = "Starting create repository %s."
+	MsgStartArchiveRepo          = "Starting archive repository %s."
+	MsgSkipRepoCollision         = "Skipping repository %s: target %s already exists."
+	MsgCompleteArchiveRepo       = "Finished archive repository %s."
+	MsgStartImportAttachments    = "Starting import attachments for repository %s."
+	MsgCompleteImportAttachments = "Finished import %d attachments for repository %s."
+	MsgStartRewriteIdentities    = "Starting rewrite of commit identities for repository %s."
+	MsgCompleteRewriteIdentities = "Finished rewrite of %d commits and tags for repository %s."
+	MsgStartInviteUsers          = "Starting invite of %d missing users."
+	MsgCompleteInviteUsers       = "Finished invite of %d missing users, %d users are still missing and are attributed to the token owner."
+	MsgWarnRewriteIdentities     = "WARNING: commit identities are rewritten, commit and tag SHAs of the imported repositories will differ from the source."
 	MsgCompleteImportCreateRepo  = "Finished create repository %s on %s."
 	MsgStartImportGit            = "Starting git push to '%s'."
 	MsgCompleteImportGit         = "Finished git push to '%s'."
 	MsgStartImportBranchRules    = "Starting importing branch rules for repository %s."
 	MsgCompleteImportBranchRules = "Finished import %d branch rules for repository %s."
+	MsgStartImportRepoSettings   = "Starting import settings for repository %s."
+	MsgCompleteImportRepoSettings = "Finished import settings for repository %s."
 	MsgStartImportPRs            = "Starting importing pull requests and comments for repository %s."
 	MsgCompleteImportPRs         = "Finished import %d pull requests with comments for repository %s."
 	MsgStartImportLabels         = "Starting importing labels for %s."
@@ -89,11 +110,14 @@ const (
 	ErrListPr                       = "cannot list pr due to error: %w"
 	ErrListComments                 = "cannot list comments for repository %s pull request %d: %w"
 	ErrListReviewers                = "cannot list reviewers for repository %s pull request %d: %w"
+	ErrListActivities               = "cannot list activities for repository %s pull request %d: %w"
+	ErrCheckpointPrActivitiesDataSave = "cannot save checkpoint pr activities data: %w"
 	ErrListBranchRules              = "cannot list branch rules for repository %s: %w"
 	ErrListBranchRulesets           = "cannot list branch rulesets for repo %s: %w"
 	ErrListBranchRuleset            = "cannot list branch ruleset %d for repo %s: %w"
 	ErrListWebhooks                 = "cannot list webhooks for repo %s: %w"
 	ErrListLabels                   = "cannot list labels for repo %s: %w"
+	ErrListSpaceLabels              = "cannot list labels for space %s: %w"
 	ErrGitPush                      = "cannot git push to '%s' due to %w. output:%s"
 	ErrGitLFSPush                   = "cannot git push LFS objects to '%s' due to %w. output:%s"
 	ErrImportBranchRules            = "cannot import branch rules for repository %s: %w"
@@ -104,6 +128,14 @@ const (
 	ErrSkipGitLFS                   = "Skipping Git LFS objects migration. If repository has LFS objects please install git and git-lfs to include them: %w"
 	ErrGitRemoteAdd                 = "cannot add remote for repository %s: %w"
 	ErrRepoLFSEnabled               = "cannot check if LFS is enabled for repository %s: %w"
+	ErrRepoSettings                 = "cannot get settings for repository %s: %w"
+	ErrImportRepoSettings           = "cannot import settings for repository %s: %w"
+	ErrArchiveRepo                  = "cannot archive repository %s: %w"
+	ErrExportAttachment             = "cannot export attachment %s for repository %s: %v"
+	ErrImportAttachment             = "cannot import attachment %s for repository %s: %v"
+	ErrRewriteIdentities            = "cannot rewrite commit identities for repository %s: %w"
+	ErrInviteUser                   = "cannot invite user %s: %v"
+	ErrRepoCollision                = "cannot import repository %s: target %s already exists"
 
 	PanicCheckpointSaveErr = "error occurred in reading checkpoint data"
 	ErrCannotCreateFolder  = "cannot create folder: %w"
@@ -0,0 +1,190 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/harness/harness-migrate/internal/common"
+	"github.com/harness/harness-migrate/internal/report"
+	"github.com/harness/harness-migrate/internal/types"
+	"github.com/harness/harness-migrate/internal/util"
+	externalTypes "github.com/harness/harness-migrate/types"
+
+	"github.com/drone/go-scm/scm"
+)
+
+// DefaultAttachmentSizeLimit is the max size of an exported attachment.
+const DefaultAttachmentSizeLimit = 10 * 1024 * 1024 // 10 MB
+
+// ErrAttachmentTooLarge is returned when an attachment exceeds the size limit.
+var ErrAttachmentTooLarge = errors.New("attachment exceeds the size limit")
+
+var (
+	// links of markdown images and links, which can be relative.
+	markdownLinkRegexp = regexp.MustCompile(`\]\(\s*<?([^)\s>]+)`)
+	// src and href attributes of html tags.
+	htmlLinkRegexp = regexp.MustCompile(`(?i)(?:src|href)\s*=\s*["']([^"']+)["']`)
+	// absolute links without markup.
+	plainLinkRegexp = regexp.MustCompile(`https?://[^\s<>"'()\[\]]+`)
+
+	unsafeFileNameRegexp = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+// extractLinks returns the unique links of the text in order of appearance.
+func extractLinks(text string, seen map[string]bool) []string {
+	var links []string
+	add := func(link string) {
+		if link == "" || seen[link] {
+			return
+		}
+		seen[link] = true
+		links = append(links, link)
+	}
+
+	for _, re := range []*regexp.Regexp{markdownLinkRegexp, htmlLinkRegexp} {
+		for _, match := range re.FindAllStringSubmatch(text, -1) {
+			add(match[1])
+		}
+	}
+	for _, match := range plainLinkRegexp.FindAllString(text, -1) {
+		// punctuation which ends a sentence is not part of the link.
+		add(strings.TrimRight(match, ".,;:!?"))
+	}
+	return links
+}
+
+// attachmentFileName returns a file name for the attachment which is safe
+// to use in the archive.
+func attachmentFileName(index int, link string) string {
+	name := link
+	if u, err := url.Parse(link); err == nil && u.Path != "" {
+		name = u.Path
+	} else if u != nil && u.Opaque != "" {
+		name = u.Opaque
+	}
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+
+	name = unsafeFileNameRegexp.ReplaceAllString(path.Base(name), "_")
+	name = strings.Trim(name, "._")
+	if len(name) > 100 {
+		name = name[len(name)-100:]
+	}
+	if name == "" {
+		name = "file"
+	}
+	return fmt.Sprintf("%d-%s", index, name)
+}
+
+// DownloadAttachment downloads the file from the path using the scm client,
+// which resolves relative paths against the client base url.
+func DownloadAttachment(ctx context.Context, client *scm.Client, path string, limit int64) ([]byte, error) {
+	res, err := client.Do(ctx, &scm.Request{
+		Method: "GET",
+		Path:   path,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.Status != 200 {
+		return nil, fmt.Errorf("unexpected status code %d", res.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, ErrAttachmentTooLarge
+	}
+	return data, nil
+}
+
+// exportAttachments downloads the attachments referenced from pull request
+// and comment bodies into the repository folder and writes their manifest.
+func (e *Exporter) exportAttachments(ctx context.Context, repo *types.RepoData, repoPath string) error {
+	repoSlug := repo.Repository.RepoSlug
+	e.Tracer.Start(common.MsgStartExportAttachments, repoSlug)
+
+	limit := e.flags.AttachmentSizeLimit
+	if limit <= 0 {
+		limit = DefaultAttachmentSizeLimit
+	}
+
+	seen := make(map[string]bool)
+	var links []string
+	for _, pr := range repo.PullRequestData {
+		links = append(links, extractLinks(pr.PullRequest.Body, seen)...)
+		for _, comment := range pr.Comments {
+			links = append(links, extractLinks(comment.Body, seen)...)
+		}
+	}
+
+	attachments := make([]externalTypes.Attachment, 0)
+	for _, link := range links {
+		data, err := e.exporter.DownloadAttachment(ctx, repoSlug, link, limit)
+		if err != nil {
+			e.Tracer.LogError(common.ErrExportAttachment, link, repoSlug, err)
+			e.Report[repoSlug].ReportError(report.ReportTypeAttachments, link, err.Error())
+			continue
+		}
+		if data == nil {
+			continue
+		}
+
+		if len(attachments) == 0 {
+			err := util.CreateFolder(filepath.Join(repoPath, externalTypes.AttachmentsDir))
+			if err != nil {
+				return fmt.Errorf(common.ErrCannotCreateFolder, err)
+			}
+		}
+
+		file := path.Join(externalTypes.AttachmentsDir, attachmentFileName(len(attachments)+1, link))
+		err = util.WriteFile(filepath.Join(repoPath, filepath.FromSlash(file)), data)
+		if err != nil {
+			return fmt.Errorf("cannot write attachment: %w", err)
+		}
+		attachments = append(attachments, externalTypes.Attachment{URL: link, File: file})
+	}
+
+	e.Report[repoSlug].ReportMetric(report.ReportTypeAttachments, len(attachments))
+	e.Tracer.Stop(common.MsgCompleteExportAttachments, len(attachments), repoSlug)
+
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	attachmentsJson, err := util.GetJson(attachments)
+	if err != nil {
+		return fmt.Errorf("cannot serialize attachments into json: %w", err)
+	}
+	err = util.WriteFile(filepath.Join(repoPath, externalTypes.AttachmentsFileName), attachmentsJson)
+	if err != nil {
+		return fmt.Errorf("couldn't write attachments into a file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitexporter
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtractLinks(t *testing.T) {
+	body := "See ![screenshot](/uploads/0123456789abcdef0123456789abcdef/image.png) and " +
+		"<img width=\"200\" src=\"https://github.com/user-attachments/assets/4b3e\" />\n" +
+		"logs at https://bitbucket.org/acme/api/downloads/build.log, again ![](/uploads/0123456789abcdef0123456789abcdef/image.png)"
+	want := []string{
+		"/uploads/0123456789abcdef0123456789abcdef/image.png",
+		"https://github.com/user-attachments/assets/4b3e",
+		"https://bitbucket.org/acme/api/downloads/build.log",
+	}
+	if diff := cmp.Diff(extractLinks(body, map[string]bool{}), want); diff != "" {
+		t.Errorf("Unexpected links")
+		t.Log(diff)
+	}
+
+	seen := map[string]bool{want[0]: true}
+	if got := extractLinks("![image](/uploads/0123456789abcdef0123456789abcdef/image.png)", seen); len(got) != 0 {
+		t.Errorf("Want links seen before to be skipped, got %v", got)
+	}
+}
+
+func TestAttachmentFileName(t *testing.T) {
+	tests := []struct {
+		link, name string
+	}{
+		{"/uploads/0123456789abcdef0123456789abcdef/my%20image.png", "1-my_image.png"},
+		{"attachment:3/3c6f9e5a1f%2Fdiagram.svg", "1-diagram.svg"},
+		{"https://github.com/user-attachments/assets/4b3e?raw=true", "1-4b3e"},
+		{"https://example.com/", "1-file"},
+	}
+	for _, test := range tests {
+		if got := attachmentFileName(1, test.link); got != test.name {
+			t.Errorf("Want file name %q for %q, got %q", test.name, test.link, got)
+		}
+	}
+}
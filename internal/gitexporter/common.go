@@ -33,6 +33,11 @@ func mapPRComment(comments []*types.PRComment) []externalTypes.Comment {
 			ParentID:    c.ParentID,
 			CodeComment: mapCodeComment(c.CodeComment),
 		}
+		if c.Resolution != nil {
+			resolvedBy := externalTypes.User(c.Resolution.ResolvedBy)
+			r[i].Resolved = &c.Resolution.Resolved
+			r[i].ResolvedBy = &resolvedBy
+		}
 	}
 	return r
 }
@@ -53,6 +58,24 @@ func mapPRReview(reviewers []*types.PRReview) []externalTypes.Review {
 	return r
 }
 
+func mapPRActivities(activities []*types.PRActivity) []externalTypes.Activity {
+	if len(activities) == 0 {
+		return nil
+	}
+	a := make([]externalTypes.Activity, len(activities))
+	for i, activity := range activities {
+		a[i] = externalTypes.Activity{
+			Type:    activity.Type,
+			Actor:   externalTypes.User(activity.Actor),
+			Created: activity.Created,
+			From:    activity.From,
+			To:      activity.To,
+			Method:  activity.Method,
+		}
+	}
+	return a
+}
+
 func mapPRReviewers(reviewers []*types.PRReviewer) []externalTypes.Reviewer {
 	r := make([]externalTypes.Reviewer, len(reviewers))
 	for i, reviewer := range reviewers {
@@ -112,7 +135,7 @@ func mapBranchRuleDefinition(d types.Definition) externalTypes.Definition {
 }
 
 func mapRepository(repository types.RepoResponse) externalTypes.Repository {
-	return externalTypes.Repository{
+	r := externalTypes.Repository{
 		Slug:           repository.RepoSlug,
 		ID:             repository.ID,
 		Namespace:      repository.Namespace,
@@ -130,6 +153,19 @@ func mapRepository(repository types.RepoResponse) externalTypes.Repository {
 		LfsObjectCount: repository.LfsObjectCount,
 		GitLFSDisabled: repository.GitLFSDisabled,
 	}
+	if s := repository.Settings; s != nil {
+		r.Description = s.Description
+		r.Topics = s.Topics
+		r.Homepage = s.Homepage
+		if s.Merge != nil {
+			r.MergeSettings = &externalTypes.MergeSettings{
+				StrategiesAllowed: s.Merge.StrategiesAllowed,
+				DeleteBranch:      s.Merge.DeleteBranch,
+				TitleFormat:       s.Merge.TitleFormat,
+			}
+		}
+	}
+	return r
 }
 
 func mapVisibility(visibility scm.Visibility) externalTypes.Visibility {
@@ -145,31 +181,47 @@ func mapVisibility(visibility scm.Visibility) externalTypes.Visibility {
 	}
 }
 
-func mapPR(request scm.PullRequest,
+func mapPR(request types.PRResponse,
 	labelsMap map[string]externalTypes.Label,
 ) externalTypes.PullRequest {
 	return externalTypes.PullRequest{
-		Number:  request.Number,
-		Title:   request.Title,
-		Body:    request.Body,
-		SHA:     request.Sha,
-		Ref:     request.Ref,
-		Source:  request.Source,
-		Target:  request.Target,
-		Fork:    request.Fork,
-		Link:    request.Link,
-		Diff:    request.Diff,
-		Draft:   request.Draft,
-		Closed:  request.Closed,
-		Merged:  request.Merged,
-		Merge:   request.Merge,
-		Base:    mapReference(request.Base),
-		Head:    mapReference(request.Head),
-		Author:  externalTypes.User(request.Author),
-		Created: request.Created,
-		Updated: request.Updated,
-		Labels:  mapLabels(request.Labels, labelsMap),
+		Number:       request.Number,
+		Title:        request.Title,
+		Body:         request.Body,
+		SHA:          request.Sha,
+		Ref:          request.Ref,
+		Source:       request.Source,
+		Target:       request.Target,
+		Fork:         request.Fork,
+		Link:         request.Link,
+		Diff:         request.Diff,
+		Draft:        request.Draft,
+		Closed:       request.Closed,
+		Merged:       request.Merged,
+		Merge:        request.Merge,
+		Base:         mapReference(request.Base),
+		Head:         mapReference(request.Head),
+		Author:       externalTypes.User(request.Author),
+		Created:      request.Created,
+		Updated:      request.Updated,
+		Labels:       mapLabels(request.Labels, labelsMap),
+		Assignees:    mapUsers(request.Assignees),
+		Milestone:    request.Milestone,
+		LinkedIssues: extractLinkedIssues(request.Body),
+		Squash:       request.Squash,
+		AutoMerge:    request.AutoMerge,
+	}
+}
+
+func mapUsers(users []scm.User) []externalTypes.User {
+	if len(users) == 0 {
+		return nil
+	}
+	u := make([]externalTypes.User, len(users))
+	for i, user := range users {
+		u[i] = externalTypes.User(user)
 	}
+	return u
 }
 
 func mapReference(reference scm.Reference) externalTypes.Reference {
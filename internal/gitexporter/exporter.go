@@ -65,6 +65,10 @@ type (
 		NoPRMetadata bool // to not export pull request comments and reviewers
 		NoLabel      bool // to not export repo/space labels
 		NoLFS        bool // to not export LFS objects
+		NoAttachment bool // to not export attachments of pull requests and comments
+		NoActivity   bool // to not export the activity timeline of pull requests
+
+		AttachmentSizeLimit int64 // max size of an exported attachment in bytes
 	}
 )
 
@@ -115,9 +119,25 @@ func (e *Exporter) Export(ctx context.Context) error {
 		return fmt.Errorf(common.ErrFetchingFileData, err)
 	}
 
+	// labels of the namespace are written once at the
+	// namespace level, and only repository labels which
+	// differ are written with the repository.
+	var spaceLabels map[string][]externalTypes.Label
+	if !e.flags.NoLabel {
+		spaceLabels, err = e.getSpaceLabels(ctx, data)
+		if err != nil {
+			return fmt.Errorf(common.ErrFetchingFileData, err)
+		}
+		if err := e.writeSpaceLabels(spaceLabels, path); err != nil {
+			return fmt.Errorf(common.ErrWritingFileData, err)
+		}
+	}
+
 	users := make(map[string]bool)
 	for _, repo := range data {
-		err = e.writeJsonForRepo(mapRepoData(repo), path)
+		repoData := mapRepoData(repo)
+		repoData.Labels = diffLabels(repoData.Labels, spaceLabels[getSpaceDir(repo.Repository.RepoSlug)])
+		err = e.writeJsonForRepo(repoData, path)
 		if err != nil {
 			return fmt.Errorf(common.ErrWritingFileData, err)
 		}
@@ -326,6 +346,14 @@ func (e *Exporter) getData(ctx context.Context, path string) ([]*types.RepoData,
 			e.Report[repo.RepoSlug].ReportSkipped(report.ReportTypeGitLFSObjects)
 		}
 
+		// get repository description, topics and merge settings
+		settings, err := e.exporter.GetRepoSettings(ctx, repo.RepoSlug)
+		if err != nil {
+			// settings are best effort and do not block the export.
+			e.Tracer.LogError(common.ErrRepoSettings, repo.RepoSlug, err)
+		}
+		repoData[i].Repository.Settings = settings
+
 		// 3. clone git data for each repo
 		isEmpty, lfsObjectCount, err := e.CloneRepository(
 			ctx, repo.Repository, repoPath, repo.RepoSlug,
@@ -418,6 +446,14 @@ func (e *Exporter) getData(ctx context.Context, path string) ([]*types.RepoData,
 			}
 
 			e.flushMergeBaseClosures(repo.RepoSlug, mergeBaseLogs)
+
+			// 8. download attachments referenced from pull requests and comments
+			if !e.flags.NoAttachment {
+				err = e.exportAttachments(ctx, repoData[i], repoPath)
+				if err != nil {
+					return nil, fmt.Errorf("error exporting attachments: %w", err)
+				}
+			}
 		}
 	}
 
@@ -473,6 +509,7 @@ func (e *Exporter) fetchPRMetadata(ctx context.Context, repoSlug string, prData
 	defer e.Tracer.Stop("Completed fetching PR review metadata for repo %s", repoSlug)
 
 	var opNotSupportedErr *codeerror.OpNotSupportedError
+	var activityCount int
 	for _, pr := range prData {
 		pr.Reviews = []*types.PRReview{}
 		pr.Reviewers = []*types.PRReviewer{}
@@ -494,6 +531,23 @@ func (e *Exporter) fetchPRMetadata(ctx context.Context, repoSlug string, prData
 		}
 
 		pr.Reviewers = requestedReviewers
+
+		if e.flags.NoActivity {
+			continue
+		}
+
+		// Fetch state changes, force pushes and merges
+		activities, err := e.exporter.ListPullRequestActivities(ctx, repoSlug, pr.PullRequest.Number)
+		if err != nil && !errors.As(err, &opNotSupportedErr) {
+			return fmt.Errorf("encountered error in getting activities for PR %d: %w",
+				pr.PullRequest.Number, err)
+		}
+		pr.Activities = activities
+		activityCount += len(activities)
+	}
+
+	if !e.flags.NoActivity {
+		e.Report[repoSlug].ReportMetric(report.ReportTypeActivities, activityCount)
 	}
 	return nil
 }
@@ -571,11 +625,22 @@ func extractUsers(repo *types.RepoData, users map[string]bool) map[string]bool {
 			repoUsers[prData.PullRequest.Author.Email] = true
 		}
 
+		for _, assignee := range prData.PullRequest.Assignees {
+			if assignee.Email != "" {
+				users[assignee.Email] = true
+				repoUsers[assignee.Email] = true
+			}
+		}
+
 		for _, comment := range prData.Comments {
 			if comment.Author.Email != "" {
 				users[comment.Author.Email] = true
 				repoUsers[comment.Author.Email] = true
 			}
+			if comment.Resolution != nil && comment.Resolution.ResolvedBy.Email != "" {
+				users[comment.Resolution.ResolvedBy.Email] = true
+				repoUsers[comment.Resolution.ResolvedBy.Email] = true
+			}
 		}
 
 		for _, review := range prData.Reviews {
@@ -585,6 +650,13 @@ func extractUsers(repo *types.RepoData, users map[string]bool) map[string]bool {
 			}
 		}
 
+		for _, activity := range prData.Activities {
+			if activity.Actor.Email != "" {
+				users[activity.Actor.Email] = true
+				repoUsers[activity.Actor.Email] = true
+			}
+		}
+
 		for _, requestedReviewer := range prData.Reviewers {
 			if requestedReviewer.User.Email != "" {
 				users[requestedReviewer.User.Email] = true
@@ -651,10 +723,11 @@ func mapRepoData(repoData *types.RepoData) *externalTypes.RepositoryData {
 	d.PullRequestData = make([]*externalTypes.PullRequestData, len(repoData.PullRequestData))
 	for i, prData := range repoData.PullRequestData {
 		d.PullRequestData[i] = new(externalTypes.PullRequestData)
-		d.PullRequestData[i].PullRequest = mapPR(prData.PullRequest.PullRequest, repoData.Labels)
+		d.PullRequestData[i].PullRequest = mapPR(prData.PullRequest, repoData.Labels)
 		d.PullRequestData[i].Comments = mapPRComment(prData.Comments)
 		d.PullRequestData[i].Reviews = mapPRReview(prData.Reviews)
 		d.PullRequestData[i].Reviewers = mapPRReviewers(prData.Reviewers)
+		d.PullRequestData[i].Activities = mapPRActivities(prData.Activities)
 	}
 
 	d.Webhooks.Hooks = mapHooks(repoData.Webhooks.ConvertedHooks)
@@ -697,6 +770,8 @@ func (e Exporter) reportSkippedMetadata(reporter *report.Report) {
 		report.ReportTypePRs:         e.flags.NoPR,
 		report.ReportTypeBranchRules: e.flags.NoRule,
 		report.ReportTypeLabels:      e.flags.NoLabel,
+		report.ReportTypeAttachments: e.flags.NoAttachment || e.flags.NoPR,
+		report.ReportTypeActivities:  e.flags.NoActivity || e.flags.NoPRMetadata || e.flags.NoPR,
 	}
 
 	for reportType, isSkipped := range reportTypesMap {
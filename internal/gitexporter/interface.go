@@ -35,6 +35,10 @@ type Interface interface {
 
 	ListRequestedReviewers(ctx context.Context, repoSlug string, prNumber int) ([]*types.PRReviewer, error)
 
+	// ListPullRequestActivities lists the state changes, force pushes and merges
+	// of a pull request, in chronological order.
+	ListPullRequestActivities(ctx context.Context, repoSlug string, prNumber int) ([]*types.PRActivity, error)
+
 	PullRequestRefs() []config.RefSpec
 
 	ListWebhooks(ctx context.Context, repoSlug string, opts types.ListOptions) (types.WebhookData, error)
@@ -43,5 +47,14 @@ type Interface interface {
 
 	ListLabels(ctx context.Context, repoSlug string, opts types.ListOptions) (map[string]externalTypes.Label, error)
 
+	ListSpaceLabels(ctx context.Context, namespace string, opts types.ListOptions) (map[string]externalTypes.Label, error)
+
 	GetLFSEnabledSettings(ctx context.Context, repoSlug string) (bool, error)
+
+	GetRepoSettings(ctx context.Context, repoSlug string) (*types.RepoSettings, error)
+
+	// DownloadAttachment downloads a file hosted on the SCM which is referenced
+	// from a pull request or comment body. It returns nil when the link does not
+	// point to an attachment of the provider.
+	DownloadAttachment(ctx context.Context, repoSlug string, link string, limit int64) ([]byte, error)
 }
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitexporter
+
+import (
+	"regexp"
+	"strings"
+)
+
+// closingIssueRE matches issue references preceded by one of the closing
+// keywords supported by GitHub, GitLab and Bitbucket, e.g. "closes #12",
+// "Fixes: acme/api#3" or "resolves https://gitlab.com/acme/api/-/issues/4".
+var closingIssueRE = regexp.MustCompile(
+	`(?i)\b(?:close[sd]?|closing|fix(?:e[sd])?|fixing|resolve[sd]?|resolving|implement(?:s|ed)?|implementing)\b:?\s+` +
+		`((?:[\w.-]+/[\w./-]+)?#\d+|https?://\S+/issues/\d+)`)
+
+// extractLinkedIssues returns the issues closed by the pull request body,
+// in order of appearance and without duplicates.
+func extractLinkedIssues(body string) []string {
+	var issues []string
+	seen := make(map[string]bool)
+	for _, match := range closingIssueRE.FindAllStringSubmatch(body, -1) {
+		issue := strings.TrimRight(match[1], ".,;:!?)")
+		if seen[issue] {
+			continue
+		}
+		seen[issue] = true
+		issues = append(issues, issue)
+	}
+	return issues
+}
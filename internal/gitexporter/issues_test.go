@@ -0,0 +1,33 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitexporter
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtractLinkedIssues(t *testing.T) {
+	body := "Fixes #12, closes acme/api#3.\n" +
+		"Resolves: https://gitlab.com/acme/api/-/issues/4\n" +
+		"Related to #7, fixed #12 again"
+
+	want := []string{"#12", "acme/api#3", "https://gitlab.com/acme/api/-/issues/4"}
+	if diff := cmp.Diff(want, extractLinkedIssues(body)); diff != "" {
+		t.Errorf("unexpected linked issues")
+		t.Log(diff)
+	}
+}
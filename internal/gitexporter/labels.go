@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitexporter
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/harness/harness-migrate/internal/report"
+	"github.com/harness/harness-migrate/internal/types"
+	"github.com/harness/harness-migrate/internal/util"
+	externalTypes "github.com/harness/harness-migrate/types"
+)
+
+// getSpaceLabels returns the labels of the namespace of each
+// repository, keyed by the namespace folder in the archive.
+func (e *Exporter) getSpaceLabels(ctx context.Context, data []*types.RepoData) (map[string][]externalTypes.Label, error) {
+	spaceLabels := make(map[string][]externalTypes.Label)
+	for _, repo := range data {
+		dir := getSpaceDir(repo.Repository.RepoSlug)
+		if _, ok := spaceLabels[dir]; ok {
+			continue
+		}
+
+		namespace := getNamespace(repo.Repository.RepoSlug)
+		labels, err := e.exporter.ListSpaceLabels(ctx, namespace, types.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("encountered error in getting space labels: %w", err)
+		}
+
+		var list []externalTypes.Label
+		for _, label := range labels {
+			list = append(list, label)
+		}
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Name != list[j].Name {
+				return list[i].Name < list[j].Name
+			}
+			return list[i].Value < list[j].Value
+		})
+		spaceLabels[dir] = list
+
+		if len(list) > 0 {
+			if _, ok := e.Report[namespace]; !ok {
+				e.Report[namespace] = report.Init(namespace)
+			}
+			e.Report[namespace].ReportMetric(report.ReportTypeLabels, len(list))
+		}
+	}
+	return spaceLabels, nil
+}
+
+// writeSpaceLabels writes the labels of each namespace next
+// to the namespace repositories.
+func (e *Exporter) writeSpaceLabels(spaceLabels map[string][]externalTypes.Label, path string) error {
+	for dir, labels := range spaceLabels {
+		if len(labels) == 0 {
+			continue
+		}
+		labelJson, err := util.GetJson(labels)
+		if err != nil {
+			return fmt.Errorf("cannot serialize space labels data into json: %w", err)
+		}
+		err = util.WriteFile(filepath.Join(path, dir, externalTypes.LabelsFileName), labelJson)
+		if err != nil {
+			return fmt.Errorf("error writing space labels json: %w", err)
+		}
+	}
+	return nil
+}
+
+// diffLabels returns the repository labels which are not
+// defined with the same values on the space.
+func diffLabels(labels, spaceLabels []externalTypes.Label) []externalTypes.Label {
+	if len(spaceLabels) == 0 {
+		return labels
+	}
+	space := make(map[externalTypes.Label]bool, len(spaceLabels))
+	for _, label := range spaceLabels {
+		space[label] = true
+	}
+	var out []externalTypes.Label
+	for _, label := range labels {
+		if !space[label] {
+			out = append(out, label)
+		}
+	}
+	return out
+}
+
+// helper function returns the archive folder of the
+// repository namespace.
+func getSpaceDir(repoSlug string) string {
+	return path.Dir(util.GetRepoDirFromRepoSlug(repoSlug))
+}
+
+// helper function returns the namespace of the repository.
+func getNamespace(repoSlug string) string {
+	if i := strings.LastIndex(repoSlug, "/"); i != -1 {
+		return repoSlug[:i]
+	}
+	return repoSlug
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitexporter
+
+import (
+	"testing"
+
+	externalTypes "github.com/harness/harness-migrate/types"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffLabels(t *testing.T) {
+	space := []externalTypes.Label{
+		{Name: "bug", Color: "red"},
+		{Name: "priority", Value: "high", Color: "orange"},
+	}
+	repo := []externalTypes.Label{
+		{Name: "bug", Color: "red"},
+		{Name: "priority", Value: "high", Color: "yellow"},
+		{Name: "docs", Color: "blue"},
+	}
+	want := []externalTypes.Label{
+		{Name: "priority", Value: "high", Color: "yellow"},
+		{Name: "docs", Color: "blue"},
+	}
+	if diff := cmp.Diff(diffLabels(repo, space), want); diff != "" {
+		t.Errorf("Unexpected repository labels")
+		t.Log(diff)
+	}
+	if diff := cmp.Diff(diffLabels(repo, nil), repo); diff != "" {
+		t.Errorf("Want all repository labels without space labels")
+		t.Log(diff)
+	}
+}
+
+func TestGetSpaceDir(t *testing.T) {
+	tests := []struct {
+		slug, dir, namespace string
+	}{
+		{"octocat/hello-world", "octocat", "octocat"},
+		{"group/sub/project", "group_sub", "group/sub"},
+	}
+	for _, test := range tests {
+		if got := getSpaceDir(test.slug); got != test.dir {
+			t.Errorf("Want space dir %q for %q, got %q", test.dir, test.slug, got)
+		}
+		if got := getNamespace(test.slug); got != test.namespace {
+			t.Errorf("Want namespace %q for %q, got %q", test.namespace, test.slug, got)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/harness/harness-migrate/types"
+)
+
+// ReadArchive reads the repositories with their branch rules,
+// webhooks and labels from an unzipped git export. Pull requests
+// are not read.
+func ReadArchive(dir string) ([]*types.RepositoryData, error) {
+	folders, err := getRepoBaseFolders(dir, "")
+	if err != nil {
+		return nil, fmt.Errorf("cannot get repo folders: %w", err)
+	}
+
+	m := new(Importer)
+	var repos []*types.RepositoryData
+	for _, f := range folders {
+		repo, err := m.ReadRepoInfo(f)
+		if errors.Is(err, ErrInvalidRepoDir) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rules, err := m.readBranchRules(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read branch rules from %q: %w", f, err)
+		}
+		hooks, err := m.readWebhooks(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read webhooks from %q: %w", f, err)
+		}
+		labels, err := m.readLabels(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read labels from %q: %w", f, err)
+		}
+
+		data := &types.RepositoryData{
+			Repository:  repo,
+			BranchRules: rules,
+		}
+		if hooks != nil {
+			data.Webhooks = *hooks
+		}
+		for _, label := range labels {
+			data.Labels = append(data.Labels, *label)
+		}
+		repos = append(repos, data)
+	}
+	return repos, nil
+}
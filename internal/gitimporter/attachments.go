@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/harness/harness-migrate/internal/common"
+	"github.com/harness/harness-migrate/internal/report"
+	"github.com/harness/harness-migrate/internal/util"
+	"github.com/harness/harness-migrate/types"
+)
+
+// ImportAttachments uploads the attachments of the repository and rewrites
+// their links in the pull request and comment bodies. Attachments which
+// cannot be imported keep their original link and are listed in the report.
+func (m *Importer) ImportAttachments(
+	repoRef string,
+	repoFolder string,
+	prs []*types.PullRequestData,
+) error {
+	attachments, err := readAttachments(repoFolder)
+	if err != nil {
+		return fmt.Errorf("failed to read attachments from %q: %w", repoFolder, err)
+	}
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	m.Tracer.Start(common.MsgStartImportAttachments, repoRef)
+	links := make(map[string]string)
+	for _, attachment := range attachments {
+		link, err := m.importAttachment(repoRef, repoFolder, attachment)
+		if err != nil {
+			m.Tracer.LogError(common.ErrImportAttachment, attachment.URL, repoRef, err)
+			m.Report[repoRef].ReportError(report.ReportTypeAttachments, attachment.URL, err.Error())
+			continue
+		}
+		links[attachment.URL] = link
+	}
+
+	rewriteAttachments(prs, links)
+
+	m.Report[repoRef].ReportMetric(report.ReportTypeAttachments, len(links))
+	m.Tracer.Stop(common.MsgCompleteImportAttachments, len(links), repoRef)
+	return nil
+}
+
+// importAttachment uploads the attachment to the repository, or copies it
+// to the static location when one is configured, and returns its new url.
+func (m *Importer) importAttachment(
+	repoRef string,
+	repoFolder string,
+	attachment types.Attachment,
+) (string, error) {
+	data, err := os.ReadFile(filepath.Join(repoFolder, filepath.FromSlash(attachment.File)))
+	if err != nil {
+		return "", err
+	}
+
+	if m.flags.AttachmentURL == "" {
+		return m.Harness.UploadAttachment(repoRef, data)
+	}
+
+	dir := filepath.Join(m.flags.AttachmentDir, filepath.FromSlash(repoRef))
+	if err := util.CreateFolder(dir); err != nil {
+		return "", err
+	}
+	name := path.Base(attachment.File)
+	if err := util.WriteFile(filepath.Join(dir, name), data); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(m.flags.AttachmentURL, "/") + "/" + path.Join(repoRef, name), nil
+}
+
+func readAttachments(repoFolder string) ([]types.Attachment, error) {
+	data, err := os.ReadFile(filepath.Join(repoFolder, types.AttachmentsFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var attachments []types.Attachment
+	if err := json.Unmarshal(data, &attachments); err != nil {
+		return nil, fmt.Errorf("error parsing attachments json: %w", err)
+	}
+	return attachments, nil
+}
+
+// rewriteAttachments replaces the links in pull request and comment bodies.
+func rewriteAttachments(prs []*types.PullRequestData, links map[string]string) {
+	if len(links) == 0 {
+		return
+	}
+
+	// longer links are replaced first so that a link is not
+	// partially replaced by a shorter link it starts with.
+	old := make([]string, 0, len(links))
+	for link := range links {
+		old = append(old, link)
+	}
+	sort.Slice(old, func(i, j int) bool {
+		if len(old[i]) != len(old[j]) {
+			return len(old[i]) > len(old[j])
+		}
+		return old[i] < old[j]
+	})
+
+	pairs := make([]string, 0, 2*len(old))
+	for _, link := range old {
+		pairs = append(pairs, link, links[link])
+	}
+	replacer := strings.NewReplacer(pairs...)
+
+	for _, pr := range prs {
+		pr.PullRequest.Body = replacer.Replace(pr.PullRequest.Body)
+		for i := range pr.Comments {
+			pr.Comments[i].Body = replacer.Replace(pr.Comments[i].Body)
+		}
+	}
+}
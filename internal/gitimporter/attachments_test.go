@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"testing"
+
+	"github.com/harness/harness-migrate/types"
+)
+
+func TestRewriteAttachments(t *testing.T) {
+	prs := []*types.PullRequestData{{
+		PullRequest: types.PullRequest{
+			Body: "![a](/uploads/1/a.png) and https://gitlab.com/acme/api/uploads/1/a.png",
+		},
+		Comments: []types.Comment{{Body: "![b](/uploads/2/b.png) ![x](/uploads/3/x.png)"}},
+	}}
+	links := map[string]string{
+		"/uploads/1/a.png": "https://harness.io/uploads/a.png",
+		"https://gitlab.com/acme/api/uploads/1/a.png": "https://harness.io/uploads/full.png",
+		"/uploads/2/b.png": "https://harness.io/uploads/b.png",
+	}
+
+	rewriteAttachments(prs, links)
+
+	if want, got := "![a](https://harness.io/uploads/a.png) and https://harness.io/uploads/full.png",
+		prs[0].PullRequest.Body; got != want {
+		t.Errorf("want pull request body %q, got %q", want, got)
+	}
+	if want, got := "![b](https://harness.io/uploads/b.png) ![x](/uploads/3/x.png)",
+		prs[0].Comments[0].Body; got != want {
+		t.Errorf("want comment body %q, got %q", want, got)
+	}
+}
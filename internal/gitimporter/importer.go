@@ -53,6 +53,19 @@ type Importer struct {
 	Tracer  tracer.Tracer
 	Report  map[string]*report.Report
 
+	// Mapping optionally routes repositories to other target
+	// spaces and identifiers.
+	Mapping *Mapping
+
+	// Identities optionally rewrites the commit identities
+	// of repositories before they are pushed.
+	Identities IdentityMapper
+
+	// Targets are the resolved targets of the repositories in
+	// the archive. They are recorded on import and can be set
+	// to verify the targets of a previous import.
+	Targets []*Target
+
 	RequestId string
 	flags     Flags
 }
@@ -65,9 +78,45 @@ type Flags struct {
 	NoRule        bool
 	NoLabel       bool
 	NoGit         bool // for incremental migration - skip git operations
+	NoActivity    bool // to not import the activity timeline of pull requests
 	PRBatchSize   int  // batch size for PR imports to avoid 413 errors (default: 100)
+
+	// InternalVisibility is the visibility of repositories with
+	// internal visibility on the source, private or public.
+	InternalVisibility string
+
+	// OnCollision is the policy for repositories whose target
+	// already exists: skip, suffix or fail.
+	OnCollision string
+
+	// InviteMissingUsers invites users missing on the target instead of
+	// failing the import, limited to the InviteDomains when set.
+	InviteMissingUsers bool
+	InviteDomains      []string
+
+	// AttachmentDir and AttachmentURL are the static location attachments
+	// are copied to and served from. Attachments are uploaded to the
+	// repository when no url is set.
+	AttachmentDir string
+	AttachmentURL string
+
+	// PRAssignees, PRMilestone, PRLinkedIssues and PRMergeOptions decide
+	// how metadata of pull requests without a Harness counterpart is
+	// imported. Assignees are added as reviewers and the milestone as
+	// label by default, linked issues and merge options are appended
+	// to the description.
+	PRAssignees    string // reviewer, footer or skip
+	PRMilestone    string // label, footer or skip
+	PRLinkedIssues string // footer or skip
+	PRMergeOptions string // footer or skip
 }
 
+// Visibility values for repositories with internal visibility.
+const (
+	VisibilityPrivate = "private"
+	VisibilityPublic  = "public"
+)
+
 func NewImporter(
 	baseURL,
 	space,
@@ -129,24 +178,25 @@ func (m *Importer) Import(ctx context.Context) error {
 		return err
 	}
 
-	importedRepos := 0
-	for _, f := range folders {
-		repository, err := m.ReadRepoInfo(f)
-		if errors.Is(err, ErrInvalidRepoDir) {
-			continue
-		}
-		if err != nil {
-			m.Tracer.LogError("failed to read repo info from %q: %s", f, err.Error())
-			continue
-		}
+	targets, err := m.resolveTargets(folders, true)
+	if err != nil {
+		return err
+	}
+
+	if m.Identities != nil && !m.flags.NoGit {
+		m.Tracer.LogError(common.MsgWarnRewriteIdentities)
+	}
 
-		repoRef := util.JoinPaths(m.HarnessSpace, repository.Name)
+	importedRepos := 0
+	importedSpaces := make(map[string]bool)
+	for _, target := range targets {
+		f, repository := target.folder, target.repo
+		repoRef := util.JoinPaths(target.space, repository.Name)
 
-		m.Report[repoRef] = report.Init(repoRef)
 		m.reportSkippedMetadata(m.Report[repoRef])
 
 		if !m.flags.NoGit {
-			if err := m.createRepoAndDoPush(ctx, f, &repository); err != nil {
+			if err := m.createRepoAndDoPush(ctx, f, target.space, &repository); err != nil {
 				m.Tracer.LogError("failed to create or push git data for %q: %s", repoRef, err.Error())
 				if !errors.Is(err, harness.ErrDuplicate) {
 					m.cleanup(repoRef)
@@ -167,6 +217,26 @@ func (m *Importer) Import(ctx context.Context) error {
 			if err != nil {
 				return fmt.Errorf("failed to update the repo state to %s: %w", enum.RepoStateMigrateDataImport, err)
 			}
+
+			// repository settings are best effort and do not abort the import.
+			if err := m.ImportRepoSettings(repoRef, &repository); err != nil {
+				m.Tracer.LogError("failed to import repo settings for %q: %s", repoRef, err.Error())
+				m.Report[repoRef].ReportError(report.ReportTypeRepoSettings, repoRef, err.Error())
+			}
+		}
+
+		// namespace labels are imported once on the target space,
+		// before the repository labels which override them.
+		spaceFolder := filepath.Dir(f)
+		if key := target.space + "|" + spaceFolder; !m.flags.NoLabel && !importedSpaces[key] {
+			importedSpaces[key] = true
+			if err := m.ImportSpaceLabels(target.space, spaceFolder); err != nil {
+				m.Tracer.LogError("failed to import labels for space %q: %s", target.space, err.Error())
+				if _, ok := m.Report[target.space]; !ok {
+					m.Report[target.space] = report.Init(target.space)
+				}
+				m.Report[target.space].ReportError(report.ReportTypeLabels, target.space, err.Error())
+			}
 		}
 
 		if !repository.IsEmpty {
@@ -194,6 +264,12 @@ func (m *Importer) Import(ctx context.Context) error {
 			return fmt.Errorf("failed to update the repo state to %s: %w", enum.RepoStateActive, err)
 		}
 
+		// archived repositories are imported as writable and
+		// archived once all data is in place.
+		if repository.Archived && !m.flags.NoGit {
+			m.archiveRepo(repoRef)
+		}
+
 		importedRepos++
 	}
 
@@ -226,6 +302,10 @@ func (m *Importer) checkUsers(unzipLocation string) error {
 	if err != nil {
 		return fmt.Errorf("error checking users: %w", err)
 	}
+	if len(unknownUsers) != 0 && m.flags.InviteMissingUsers {
+		m.InviteUsers(unknownUsers)
+		return nil
+	}
 	if len(unknownUsers) != 0 {
 		return fmt.Errorf("users not present in system: %v", unknownUsers)
 	}
@@ -233,8 +313,16 @@ func (m *Importer) checkUsers(unzipLocation string) error {
 	return nil
 }
 
-func (m *Importer) createRepoAndDoPush(ctx context.Context, repoFolder string, repo *types.Repository) error {
-	hRepo, err := m.CreateRepo(repo, m.HarnessSpace, m.Tracer)
+func (m *Importer) createRepoAndDoPush(ctx context.Context, repoFolder, space string, repo *types.Repository) error {
+	// identities are rewritten before the repository is created
+	// as the rewrite can fail.
+	if m.Identities != nil && !repo.IsEmpty {
+		if err := m.RewriteIdentities(util.JoinPaths(space, repo.Name), repoFolder); err != nil {
+			return err
+		}
+	}
+
+	hRepo, err := m.CreateRepo(repo, space, m.Tracer)
 	if err != nil {
 		return fmt.Errorf("failed to create repo: %w", err)
 	}
@@ -243,7 +331,7 @@ func (m *Importer) createRepoAndDoPush(ctx context.Context, repoFolder string, r
 		return nil
 	}
 
-	repoRef := util.JoinPaths(m.HarnessSpace, repo.Name)
+	repoRef := util.JoinPaths(space, repo.Name)
 	originalLimit, err := m.getFileSizeLimit(repoRef, m.Tracer)
 	if err != nil {
 		return fmt.Errorf("failed to get repo file size limit: %w", err)
@@ -306,6 +394,18 @@ func (m *Importer) importRepoMetaData(_ context.Context, repoRef, repoFolder str
 	return nil
 }
 
+// archiveRepo switches the repo to the archived state best effort.
+func (m *Importer) archiveRepo(repoRef string) {
+	m.Tracer.Start(common.MsgStartArchiveRepo, repoRef)
+	if err := m.Harness.ArchiveRepository(repoRef); err != nil {
+		m.Tracer.Stop(common.ErrArchiveRepo, repoRef, err)
+		m.Report[repoRef].ReportError(report.ReportTypeRepoSettings, "archived", err.Error())
+		return
+	}
+	m.Report[repoRef].ReportDetail(report.ReportTypeRepoSettings, "archived", "imported as archived (read-only)")
+	m.Tracer.Stop(common.MsgCompleteArchiveRepo, repoRef)
+}
+
 // Cleanup cleans up the repo best effort.
 func (m *Importer) cleanup(repoRef string) {
 	m.Tracer.Start(common.MsgStartRepoCleanup, repoRef)
@@ -53,6 +53,35 @@ func (m *Importer) ImportLabels(
 	return nil
 }
 
+// ImportSpaceLabels imports the labels of the source namespace,
+// stored in the namespace folder, into the target space.
+func (m *Importer) ImportSpaceLabels(
+	space string,
+	spaceFolder string,
+) error {
+	in, err := m.readLabels(spaceFolder)
+	if err != nil {
+		return fmt.Errorf("failed to read labels from %q: %w", spaceFolder, err)
+	}
+	if len(in) == 0 {
+		return nil
+	}
+
+	m.Tracer.Start(common.MsgStartImportLabels, space)
+	if _, ok := m.Report[space]; !ok {
+		m.Report[space] = report.Init(space)
+	}
+	if err := m.Harness.ImportSpaceLabels(space, &types.LabelsInput{Labels: in}); err != nil {
+		m.Tracer.Stop(common.ErrImportLabels, space, err)
+		return fmt.Errorf("failed to import labels for '%s' : %w",
+			space, err)
+	}
+
+	m.Report[space].ReportMetric(report.ReportTypeLabels, len(in))
+	m.Tracer.Stop(common.MsgCompleteImportLabels, len(in), space)
+	return nil
+}
+
 func (m *Importer) readLabels(repoFolder string) ([]*types.Label, error) {
 	labelsFile := filepath.Join(repoFolder, types.LabelsFileName)
 	var labels []*types.Label
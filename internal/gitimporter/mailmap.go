@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// mailmapRegexp matches a mailmap line: a name and email, optionally
+// followed by the name and email found in commits.
+var mailmapRegexp = regexp.MustCompile(`^([^<]*)<([^>]*)>\s*(?:([^<]*)<([^>]*)>)?\s*(?:#.*)?$`)
+
+type (
+	// Mailmap maps commit identities using the format of git .mailmap files.
+	Mailmap struct {
+		entries []*mailmapEntry
+	}
+
+	mailmapEntry struct {
+		name        string
+		email       string
+		commitName  string
+		commitEmail string
+	}
+)
+
+// LoadMailmap reads a .mailmap file.
+func LoadMailmap(file string) (*Mailmap, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mailmap %q: %w", file, err)
+	}
+	defer f.Close()
+
+	mailmap := new(Mailmap)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		match := mailmapRegexp.FindStringSubmatch(text)
+		if match == nil {
+			return nil, fmt.Errorf("failed to parse mailmap %q: invalid line %d", file, line)
+		}
+
+		entry := &mailmapEntry{name: strings.TrimSpace(match[1])}
+		if match[4] == "" {
+			// Proper Name <commit@email>
+			entry.commitEmail = match[2]
+		} else {
+			// Proper Name <proper@email> Commit Name <commit@email>
+			entry.email = match[2]
+			entry.commitName = strings.TrimSpace(match[3])
+			entry.commitEmail = match[4]
+		}
+		mailmap.entries = append(mailmap.entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read mailmap %q: %w", file, err)
+	}
+	return mailmap, nil
+}
+
+// MapIdentity returns the proper name and email of a commit identity.
+// Entries matching the name and email take precedence over entries
+// matching the email only.
+func (m *Mailmap) MapIdentity(name, email string) (string, string) {
+	var match *mailmapEntry
+	for _, entry := range m.entries {
+		if !strings.EqualFold(entry.commitEmail, email) {
+			continue
+		}
+		if entry.commitName == "" && (match == nil || match.commitName == "") {
+			match = entry
+		} else if strings.EqualFold(entry.commitName, name) {
+			match = entry
+		}
+	}
+	if match == nil {
+		return name, email
+	}
+
+	if match.name != "" {
+		name = match.name
+	}
+	if match.email != "" {
+		email = match.email
+	}
+	return name, email
+}
@@ -0,0 +1,299 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/harness/harness-migrate/internal/common"
+	"github.com/harness/harness-migrate/internal/report"
+	"github.com/harness/harness-migrate/internal/util"
+	"github.com/harness/harness-migrate/types"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Collision policies for repositories whose target already
+// exists or is claimed by another repository in the archive.
+const (
+	CollisionSkip   = "skip"
+	CollisionSuffix = "suffix"
+	CollisionFail   = "fail"
+)
+
+var errEmptySource = errors.New("mapping rule without source")
+
+type (
+	// Mapping routes source repositories to target spaces
+	// and repository identifiers.
+	Mapping struct {
+		Rules []*MappingRule `yaml:"mappings"`
+	}
+
+	// MappingRule maps the source repositories matching the
+	// source slug or wildcard pattern (e.g. org/*). A ** segment
+	// matches any number of nested groups (e.g. org/**). An empty
+	// space keeps the import space and an empty identifier
+	// keeps the source repository name.
+	MappingRule struct {
+		Source     string `yaml:"source"`
+		Space      string `yaml:"space"`
+		Identifier string `yaml:"identifier"`
+	}
+
+	// Target is the resolved target of a repository in the
+	// archive, recorded on import and read by the verification.
+	// Skipped repositories were not imported.
+	Target struct {
+		Source  string `json:"source"`
+		Target  string `json:"target,omitempty"`
+		Skipped bool   `json:"skipped,omitempty"`
+	}
+
+	// repoTarget is a repository folder resolved to its
+	// target space.
+	repoTarget struct {
+		folder string
+		space  string
+		repo   types.Repository
+	}
+)
+
+// LoadMapping reads the mapping from a yaml or csv file. Csv
+// rows are source, space and an optional identifier.
+func LoadMapping(file string) (*Mapping, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file %q: %w", file, err)
+	}
+
+	mapping := new(Mapping)
+	if strings.EqualFold(filepath.Ext(file), ".csv") {
+		mapping.Rules, err = parseMappingCSV(data)
+	} else {
+		err = yaml.Unmarshal(data, mapping)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file %q: %w", file, err)
+	}
+
+	for _, rule := range mapping.Rules {
+		if rule.Source == "" {
+			return nil, fmt.Errorf("failed to parse mapping file %q: %w", file, errEmptySource)
+		}
+		rule.Space = strings.Trim(rule.Space, "/")
+	}
+	return mapping, nil
+}
+
+// LoadTargets reads the targets written on import.
+func LoadTargets(file string) ([]*Target, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read targets file %q: %w", file, err)
+	}
+	var targets []*Target
+	if err := json.Unmarshal(data, &targets); err != nil {
+		return nil, fmt.Errorf("failed to parse targets file %q: %w", file, err)
+	}
+	return targets, nil
+}
+
+// WriteTargets writes the targets resolved on import.
+func WriteTargets(file string, targets []*Target) error {
+	data, err := json.MarshalIndent(targets, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("failed to write targets file %q: %w", file, err)
+	}
+	return nil
+}
+
+func parseMappingCSV(data []byte) ([]*MappingRule, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	var rules []*MappingRule
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// skip the optional header row.
+		if len(rules) == 0 && strings.EqualFold(record[0], "source") {
+			continue
+		}
+		if len(record) < 2 || len(record) > 3 {
+			return nil, fmt.Errorf("expected source, space and optional identifier, got %d fields", len(record))
+		}
+		rule := &MappingRule{Source: record[0], Space: record[1]}
+		if len(record) == 3 {
+			rule.Identifier = record[2]
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Resolve returns the target space and identifier for the source
+// repository slug. Exact rules take precedence over wildcard rules,
+// otherwise the first matching rule wins.
+func (m *Mapping) Resolve(slug, space, name string) (string, string) {
+	rule := m.match(slug)
+	if rule == nil {
+		return space, name
+	}
+	if rule.Space != "" {
+		space = rule.Space
+	}
+	if rule.Identifier != "" {
+		name = rule.Identifier
+	}
+	return space, name
+}
+
+func (m *Mapping) match(slug string) *MappingRule {
+	for _, rule := range m.Rules {
+		if strings.EqualFold(rule.Source, slug) {
+			return rule
+		}
+	}
+	for _, rule := range m.Rules {
+		if matchSource(strings.Split(rule.Source, "/"), strings.Split(slug, "/")) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// matchSource matches the slug segments against the pattern
+// segments. A ** segment matches zero or more segments, other
+// segments are matched with path.Match.
+func matchSource(pattern, slug []string) bool {
+	if len(pattern) == 0 {
+		return len(slug) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(slug); i++ {
+			if matchSource(pattern[1:], slug[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(slug) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], slug[0]); !ok {
+		return false
+	}
+	return matchSource(pattern[1:], slug[1:])
+}
+
+// resolveTargets reads the repository folders and resolves the
+// target of each repository, applying the mapping and the
+// collision policy. Existing repositories on the server are
+// only considered taken when checkServer is set. The resolved
+// and skipped targets are recorded in m.Targets.
+func (m *Importer) resolveTargets(folders []string, checkServer bool) ([]*repoTarget, error) {
+	var targets []*repoTarget
+	claimed := make(map[string]bool)
+
+	for _, f := range folders {
+		repository, err := m.ReadRepoInfo(f)
+		if errors.Is(err, ErrInvalidRepoDir) {
+			continue
+		}
+		if err != nil {
+			m.Tracer.LogError("failed to read repo info from %q: %s", f, err.Error())
+			continue
+		}
+
+		space, identifier := m.HarnessSpace, repository.Name
+		if m.Mapping != nil {
+			space, identifier = m.Mapping.Resolve(repository.Slug, space, identifier)
+		}
+
+		repoRef := util.JoinPaths(space, identifier)
+		if m.isTaken(repoRef, claimed, checkServer) {
+			switch m.flags.OnCollision {
+			case CollisionFail:
+				return nil, fmt.Errorf(common.ErrRepoCollision, repository.Slug, repoRef)
+			case CollisionSuffix:
+				identifier = m.suffixIdentifier(space, identifier, claimed, checkServer)
+				repoRef = util.JoinPaths(space, identifier)
+			default:
+				m.Tracer.Log(common.MsgSkipRepoCollision, repository.Slug, repoRef)
+				m.Report[repository.Slug] = report.Init(repository.Slug)
+				m.Report[repository.Slug].ReportDetail(report.ReportTypeMapping, repository.Slug,
+					fmt.Sprintf("skipped, %s already exists", repoRef))
+				m.Targets = append(m.Targets, &Target{Source: repository.Slug, Skipped: true})
+				continue
+			}
+		}
+		claimed[repoRef] = true
+
+		m.Report[repoRef] = report.Init(repoRef)
+		if m.Mapping != nil {
+			m.Report[repoRef].ReportDetail(report.ReportTypeMapping, repository.Slug, repoRef)
+		}
+
+		repository.Name = identifier
+		m.Targets = append(m.Targets, &Target{Source: repository.Slug, Target: repoRef})
+		targets = append(targets, &repoTarget{folder: f, space: space, repo: repository})
+	}
+	return targets, nil
+}
+
+// isTaken returns true if the target is claimed by another
+// repository in the archive or already exists on the server.
+// Existing repositories are expected for incremental imports.
+func (m *Importer) isTaken(repoRef string, claimed map[string]bool, checkServer bool) bool {
+	if claimed[repoRef] {
+		return true
+	}
+	if !checkServer || m.flags.NoGit {
+		return false
+	}
+	_, err := m.Harness.GetRepository(repoRef)
+	return err == nil
+}
+
+// suffixIdentifier returns the identifier with the lowest
+// numeric suffix that is not taken.
+func (m *Importer) suffixIdentifier(space, identifier string, claimed map[string]bool, checkServer bool) string {
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", identifier, i)
+		if !m.isTaken(util.JoinPaths(space, candidate), claimed, checkServer) {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/harness/harness-migrate/internal/report"
+	"github.com/harness/harness-migrate/internal/tracer"
+)
+
+func TestMapping(t *testing.T) {
+	for _, file := range []string{"testdata/mapping.yaml", "testdata/mapping.csv"} {
+		mapping, err := LoadMapping(file)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		tests := []struct {
+			slug, name   string
+			space, ident string
+		}{
+			{"acme/legacy-api", "legacy-api", "acc/platform/archive", "api"},
+			{"acme/web", "web", "acc/platform/default", "web"},
+			{"tools/cli", "cli", "acc/org/project", "tools"},
+			{"other/repo", "repo", "acc/org/project", "repo"},
+			{"legacy/app", "app", "acc/legacy", "app"},
+			{"legacy/group/sub/app", "app", "acc/legacy", "app"},
+			{"acme/group/app", "app", "acc/org/project", "app"},
+		}
+		for _, test := range tests {
+			space, ident := mapping.Resolve(test.slug, "acc/org/project", test.name)
+			if space != test.space || ident != test.ident {
+				t.Errorf("%s: want %s/%s for %s, got %s/%s", file, test.space, test.ident, test.slug, space, ident)
+			}
+		}
+	}
+}
+
+func TestMappingInvalid(t *testing.T) {
+	if _, err := parseMappingCSV([]byte("acme/web")); err == nil {
+		t.Errorf("Want error for csv row without space")
+	}
+}
+
+func TestResolveTargets(t *testing.T) {
+	dir := t.TempDir()
+	var folders []string
+	for _, slug := range []string{"acme/api", "tools/api"} {
+		folder := filepath.Join(dir, slug)
+		if err := os.MkdirAll(folder, 0755); err != nil {
+			t.Fatal(err)
+		}
+		info := fmt.Sprintf(`{"slug": %q, "name": "api"}`, slug)
+		if err := os.WriteFile(filepath.Join(folder, "info.json"), []byte(info), 0644); err != nil {
+			t.Fatal(err)
+		}
+		folders = append(folders, folder)
+	}
+
+	tests := []struct {
+		policy string
+		want   []*Target
+	}{
+		{CollisionSkip, []*Target{
+			{Source: "acme/api", Target: "acc/org/api"},
+			{Source: "tools/api", Skipped: true},
+		}},
+		{CollisionSuffix, []*Target{
+			{Source: "acme/api", Target: "acc/org/api"},
+			{Source: "tools/api", Target: "acc/org/api-1"},
+		}},
+	}
+	for _, test := range tests {
+		importer := &Importer{
+			HarnessSpace: "acc/org",
+			Tracer:       tracer.Default(),
+			Report:       map[string]*report.Report{},
+			flags:        Flags{OnCollision: test.policy},
+		}
+		if _, err := importer.resolveTargets(folders, false); err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(importer.Targets, test.want) {
+			t.Errorf("%s: unexpected targets %v", test.policy, importer.Targets)
+		}
+	}
+
+	importer := &Importer{
+		HarnessSpace: "acc/org",
+		Tracer:       tracer.Default(),
+		Report:       map[string]*report.Report{},
+		flags:        Flags{OnCollision: CollisionFail},
+	}
+	if _, err := importer.resolveTargets(folders, false); err == nil {
+		t.Errorf("want error for colliding targets")
+	}
+}
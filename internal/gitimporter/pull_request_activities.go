@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/harness/harness-migrate/types"
+)
+
+// activityAuthor is the author of the activity comments. It has no
+// email, so the comments are attributed to the owner of the import
+// token like system comments, and the actor is named in the body.
+var activityAuthor = types.User{Name: "Harness Migrate"}
+
+// applyPRActivities adds the activities of the pull requests as comments
+// of the activityAuthor with the original timestamp, and returns the
+// number of activities added. Activities are dropped when skipped by
+// the flags.
+func (m *Importer) applyPRActivities(prs []*types.PullRequestData) int {
+	var count int
+	for _, pr := range prs {
+		activities := pr.Activities
+		pr.Activities = nil
+		if m.flags.NoActivity || len(activities) == 0 {
+			continue
+		}
+
+		// activity comments are numbered after the source
+		// comments so they can not collide with a parent id.
+		var id int
+		for _, comment := range pr.Comments {
+			id = max(id, comment.ID)
+		}
+
+		for _, activity := range activities {
+			id++
+			pr.Comments = append(pr.Comments, types.Comment{
+				ID:      id,
+				Body:    renderActivity(activity),
+				Author:  activityAuthor,
+				Created: activity.Created,
+				Updated: activity.Created,
+			})
+		}
+		sort.SliceStable(pr.Comments, func(i, j int) bool {
+			return pr.Comments[i].Created.Before(pr.Comments[j].Created)
+		})
+		count += len(activities)
+	}
+	return count
+}
+
+// renderActivity returns the comment body of an activity, naming the
+// actor since the comment is attributed to the activityAuthor.
+func renderActivity(activity types.Activity) string {
+	var event string
+	switch activity.Type {
+	case types.ActivityClosed:
+		event = "closed this pull request"
+	case types.ActivityReopened:
+		event = "reopened this pull request"
+	case types.ActivityMerged:
+		event = "merged this pull request"
+		if activity.Method != "" {
+			event += " via " + activity.Method
+		}
+		if activity.To != "" {
+			event += fmt.Sprintf(" as `%s`", shortSHA(activity.To))
+		}
+	case types.ActivityForcePushed:
+		event = "force-pushed the source branch"
+		if activity.From != "" && activity.To != "" {
+			event += fmt.Sprintf(" from `%s` to `%s`", shortSHA(activity.From), shortSHA(activity.To))
+		} else if activity.To != "" {
+			event += fmt.Sprintf(" to `%s`", shortSHA(activity.To))
+		}
+	case types.ActivityReadyForReview:
+		event = "marked this pull request as ready for review"
+	case types.ActivityDraft:
+		event = "marked this pull request as draft"
+	default:
+		event = string(activity.Type)
+	}
+	return fmt.Sprintf("_%s %s_", displayUser(activity.Actor), event)
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/harness-migrate/types"
+)
+
+func TestApplyPRActivities(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	prs := []*types.PullRequestData{{
+		Comments: []types.Comment{
+			{ID: 7, Body: "looks good", Created: day(1)},
+			{ID: 9, Body: "thanks", Created: day(3)},
+		},
+		Activities: []types.Activity{
+			{Type: types.ActivityForcePushed, Actor: types.User{Login: "jane"}, Created: day(2),
+				From: "0123456789abcdef", To: "fedcba9876543210"},
+			{Type: types.ActivityMerged, Actor: types.User{Name: "John"}, Created: day(4),
+				To: "aaaaaaaaaaaa", Method: "squash"},
+		},
+	}}
+
+	if count := new(Importer).applyPRActivities(prs); count != 2 {
+		t.Errorf("want 2 activities, got %d", count)
+	}
+
+	comments := prs[0].Comments
+	if len(comments) != 4 || prs[0].Activities != nil {
+		t.Fatalf("expect activities to be converted to comments")
+	}
+	if want := "_@jane force-pushed the source branch from `0123456` to `fedcba9`_"; comments[1].Body != want {
+		t.Errorf("want comment %q, got %q", want, comments[1].Body)
+	}
+	if comments[1].ID != 10 || !comments[1].Created.Equal(day(2)) || comments[1].Author != activityAuthor {
+		t.Errorf("unexpected force push comment %+v", comments[1])
+	}
+	if comments[1].Resolved != nil || comments[1].ResolvedBy != nil {
+		t.Errorf("expect activity comment not to be resolved")
+	}
+	if want := "_John merged this pull request via squash as `aaaaaaa`_"; comments[3].Body != want {
+		t.Errorf("want comment %q, got %q", want, comments[3].Body)
+	}
+}
+
+func TestApplyPRActivitiesSkipped(t *testing.T) {
+	prs := []*types.PullRequestData{{
+		Activities: []types.Activity{{Type: types.ActivityClosed}},
+	}}
+
+	importer := &Importer{flags: Flags{NoActivity: true}}
+	if count := importer.applyPRActivities(prs); count != 0 || len(prs[0].Comments) != 0 {
+		t.Errorf("expect skipped activities not to be imported")
+	}
+}
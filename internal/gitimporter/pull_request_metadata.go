@@ -0,0 +1,157 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harness/harness-migrate/types"
+)
+
+// Modes for metadata of pull requests which has no direct
+// counterpart on Harness.
+const (
+	MetadataReviewer = "reviewer" // assignees are added as reviewers
+	MetadataLabel    = "label"    // the milestone is added as label
+	MetadataFooter   = "footer"   // appended to the pull request description
+	MetadataSkip     = "skip"     // not imported
+)
+
+// MilestoneLabel is the key of the label milestones are imported as.
+const MilestoneLabel = "milestone"
+
+const metadataFooterTitle = "**Migrated metadata**"
+
+// applyPRMetadata maps the assignees, milestone, linked issues and merge
+// options of the pull requests to reviewers, labels or a description
+// footer as configured, and returns the milestone labels to create on
+// the repository. The metadata fields are cleared once applied.
+func (m *Importer) applyPRMetadata(prs []*types.PullRequestData) []*types.Label {
+	var labels []*types.Label
+	milestones := make(map[string]bool)
+
+	for _, pr := range prs {
+		var footer []string
+		in := &pr.PullRequest
+
+		switch m.flags.PRAssignees {
+		case MetadataSkip:
+		case MetadataFooter:
+			if len(in.Assignees) != 0 {
+				footer = append(footer, "Assignees: "+displayUsers(in.Assignees))
+			}
+		default:
+			pr.Reviewers = addReviewers(pr.Reviewers, in.Author, in.Assignees)
+		}
+
+		switch m.flags.PRMilestone {
+		case MetadataSkip:
+		case MetadataFooter:
+			if in.Milestone != "" {
+				footer = append(footer, "Milestone: "+in.Milestone)
+			}
+		default:
+			if in.Milestone != "" {
+				label := types.Label{Name: MilestoneLabel, Value: in.Milestone}
+				in.Labels = append(in.Labels, label)
+				if !milestones[in.Milestone] {
+					milestones[in.Milestone] = true
+					labels = append(labels, &label)
+				}
+			}
+		}
+
+		if m.flags.PRLinkedIssues != MetadataSkip && len(in.LinkedIssues) != 0 {
+			footer = append(footer, "Linked issues: "+strings.Join(in.LinkedIssues, ", "))
+		}
+
+		if m.flags.PRMergeOptions != MetadataSkip {
+			if in.Squash {
+				footer = append(footer, "Squash commits: yes")
+			}
+			if in.AutoMerge {
+				footer = append(footer, "Auto merge: yes")
+			}
+		}
+
+		if len(footer) != 0 {
+			in.Body = appendMetadataFooter(in.Body, footer)
+		}
+
+		in.Assignees = nil
+		in.Milestone = ""
+		in.LinkedIssues = nil
+		in.Squash = false
+		in.AutoMerge = false
+	}
+
+	return labels
+}
+
+// addReviewers adds the assignees which are neither the author nor
+// a reviewer already to the reviewers.
+func addReviewers(reviewers []types.Reviewer, author types.User, assignees []types.User) []types.Reviewer {
+	exists := map[string]bool{strings.ToLower(author.Email): true}
+	for _, reviewer := range reviewers {
+		exists[strings.ToLower(reviewer.User.Email)] = true
+	}
+
+	for _, assignee := range assignees {
+		email := strings.ToLower(assignee.Email)
+		if email == "" || exists[email] {
+			continue
+		}
+		exists[email] = true
+		reviewers = append(reviewers, types.Reviewer{User: assignee})
+	}
+	return reviewers
+}
+
+func displayUsers(users []types.User) string {
+	names := make([]string, len(users))
+	for i, user := range users {
+		names[i] = displayUser(user)
+	}
+	return strings.Join(names, ", ")
+}
+
+// displayUser returns the source login of the user, or its
+// name or email when the login is unknown.
+func displayUser(user types.User) string {
+	switch {
+	case user.Login != "":
+		return "@" + user.Login
+	case user.Name != "":
+		return user.Name
+	default:
+		return user.Email
+	}
+}
+
+func appendMetadataFooter(body string, lines []string) string {
+	var b strings.Builder
+	if body != "" {
+		b.WriteString(strings.TrimRight(body, "\n"))
+		b.WriteString("\n\n")
+	}
+	b.WriteString("---\n")
+	b.WriteString(metadataFooterTitle)
+	b.WriteString("\n")
+	for _, line := range lines {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+	return b.String()
+}
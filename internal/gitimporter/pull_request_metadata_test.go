@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"testing"
+
+	"github.com/harness/harness-migrate/types"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testMetadataPRs() []*types.PullRequestData {
+	return []*types.PullRequestData{{
+		PullRequest: types.PullRequest{
+			Body:   "Adds the api.",
+			Author: types.User{Email: "jane@acme.com"},
+			Assignees: []types.User{
+				{Login: "jane", Email: "jane@acme.com"},
+				{Login: "john", Email: "john@acme.com"},
+			},
+			Milestone:    "v1.0",
+			LinkedIssues: []string{"#12"},
+			Squash:       true,
+		},
+		Reviewers: []types.Reviewer{{User: types.User{Email: "eve@acme.com"}}},
+	}}
+}
+
+func TestApplyPRMetadata(t *testing.T) {
+	prs := testMetadataPRs()
+	labels := new(Importer).applyPRMetadata(prs)
+
+	pr := prs[0]
+	if want := []*types.Label{{Name: MilestoneLabel, Value: "v1.0"}}; !cmp.Equal(want, labels) {
+		t.Errorf("unexpected milestone labels %v", labels)
+	}
+	if want := []types.Label{{Name: MilestoneLabel, Value: "v1.0"}}; !cmp.Equal(want, pr.PullRequest.Labels) {
+		t.Errorf("unexpected pull request labels %v", pr.PullRequest.Labels)
+	}
+	if got := len(pr.Reviewers); got != 2 || pr.Reviewers[1].User.Email != "john@acme.com" {
+		t.Errorf("expect assignee john to be added as reviewer, got %v", pr.Reviewers)
+	}
+
+	want := "Adds the api.\n\n---\n**Migrated metadata**\n- Linked issues: #12\n- Squash commits: yes\n"
+	if diff := cmp.Diff(want, pr.PullRequest.Body); diff != "" {
+		t.Errorf("unexpected description")
+		t.Log(diff)
+	}
+	if pr.PullRequest.Assignees != nil || pr.PullRequest.Milestone != "" || pr.PullRequest.Squash {
+		t.Errorf("expect metadata to be cleared once applied")
+	}
+}
+
+func TestApplyPRMetadataFooter(t *testing.T) {
+	prs := testMetadataPRs()
+	importer := &Importer{flags: Flags{
+		PRAssignees:    MetadataFooter,
+		PRMilestone:    MetadataFooter,
+		PRLinkedIssues: MetadataSkip,
+		PRMergeOptions: MetadataSkip,
+	}}
+	if labels := importer.applyPRMetadata(prs); len(labels) != 0 {
+		t.Errorf("expect no milestone labels, got %v", labels)
+	}
+
+	pr := prs[0]
+	if len(pr.Reviewers) != 1 || len(pr.PullRequest.Labels) != 0 {
+		t.Errorf("expect reviewers and labels to be unchanged")
+	}
+	want := "Adds the api.\n\n---\n**Migrated metadata**\n- Assignees: @jane, @john\n- Milestone: v1.0\n"
+	if diff := cmp.Diff(want, pr.PullRequest.Body); diff != "" {
+		t.Errorf("unexpected description")
+		t.Log(diff)
+	}
+}
@@ -52,6 +52,23 @@ func (m *Importer) ImportPullRequests(
 		return nil
 	}
 
+	if err := m.ImportAttachments(repoRef, repoFolder, in); err != nil {
+		m.Tracer.Stop(common.ErrImportPRs, repoRef, err)
+		return err
+	}
+
+	if activities := m.applyPRActivities(in); activities != 0 {
+		m.Report[repoRef].ReportMetric(report.ReportTypeActivities, activities)
+	}
+
+	// milestone labels are best effort and do not abort the import.
+	if labels := m.applyPRMetadata(in); len(labels) != 0 && !m.flags.NoLabel {
+		if err := m.Harness.ImportLabels(repoRef, &types.LabelsInput{Labels: labels}); err != nil {
+			m.Tracer.LogError("failed to import milestone labels for %q: %s", repoRef, err.Error())
+			m.Report[repoRef].ReportError(report.ReportTypeLabels, repoRef, err.Error())
+		}
+	}
+
 	batchSize := DefaultPRBatchSize
 	if m.flags.PRBatchSize > 0 {
 		batchSize = m.flags.PRBatchSize
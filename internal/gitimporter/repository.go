@@ -19,6 +19,9 @@ import (
 
 	"github.com/harness/harness-migrate/internal/common"
 	"github.com/harness/harness-migrate/internal/harness"
+	"github.com/harness/harness-migrate/internal/report"
+	"github.com/harness/harness-migrate/internal/types/enum"
+	"github.com/harness/harness-migrate/internal/util"
 	"github.com/harness/harness-migrate/types"
 
 	"github.com/harness/harness-migrate/internal/tracer"
@@ -33,7 +36,7 @@ func (m *Importer) CreateRepo(
 	in := &harness.CreateRepositoryForMigrateInput{
 		Identifier:    repo.Name,
 		DefaultBranch: repo.Branch,
-		IsPublic:      !repo.Private,
+		IsPublic:      m.isPublic(repo, targetSpace),
 		ParentRef:     targetSpace,
 	}
 
@@ -47,6 +50,115 @@ func (m *Importer) CreateRepo(
 	return repoOut, nil
 }
 
+// isPublic returns whether the repo is created public. Internal
+// repositories follow the configured internal visibility.
+func (m *Importer) isPublic(repo *types.Repository, targetSpace string) bool {
+	switch repo.Visibility {
+	case types.VisibilityPublic:
+		return true
+	case types.VisibilityPrivate:
+		return false
+	case types.VisibilityInternal:
+		public := m.flags.InternalVisibility == VisibilityPublic
+		visibility := VisibilityPrivate
+		if public {
+			visibility = VisibilityPublic
+		}
+		repoRef := util.JoinPaths(targetSpace, repo.Name)
+		if r, ok := m.Report[repoRef]; ok {
+			r.ReportDetail(report.ReportTypeRepoSettings, "visibility", "internal imported as "+visibility)
+		}
+		return public
+	default:
+		return !repo.Private
+	}
+}
+
+// mergeSettingsRule is the identifier of the rule enforcing
+// the repository-wide merge settings.
+const mergeSettingsRule = "merge_settings"
+
+// ImportRepoSettings applies the repository description, topics
+// and merge settings to the created repository.
+func (m *Importer) ImportRepoSettings(
+	repoRef string,
+	repo *types.Repository,
+) error {
+	m.Tracer.Start(common.MsgStartImportRepoSettings, repoRef)
+
+	if in := convertRepoUpdate(repo); in != nil {
+		if _, err := m.Harness.UpdateRepository(repoRef, in); err != nil {
+			m.Tracer.Stop(common.ErrImportRepoSettings, repoRef, err)
+			return fmt.Errorf(common.ErrImportRepoSettings, repoRef, err)
+		}
+	}
+
+	if merge := repo.MergeSettings; merge != nil && !m.flags.NoRule {
+		if len(merge.StrategiesAllowed) > 0 || merge.DeleteBranch {
+			rules, err := convertBranchRulesToRules([]*types.BranchRule{convertMergeSettings(merge)})
+			if err != nil {
+				m.Tracer.Stop(common.ErrImportRepoSettings, repoRef, err)
+				return fmt.Errorf(common.ErrImportRepoSettings, repoRef, err)
+			}
+			err = m.Harness.ImportRules(repoRef, &types.RulesInput{Rules: rules, Type: types.RuleTypeBranch})
+			if err != nil {
+				m.Tracer.Stop(common.ErrImportRepoSettings, repoRef, err)
+				return fmt.Errorf(common.ErrImportRepoSettings, repoRef, err)
+			}
+		}
+		// harness has no default pull request title format.
+		if merge.TitleFormat != "" {
+			m.Report[repoRef].ReportDetail(report.ReportTypeRepoSettings, "merge title format",
+				fmt.Sprintf("%q is not supported", merge.TitleFormat))
+		}
+	}
+
+	m.Tracer.Stop(common.MsgCompleteImportRepoSettings, repoRef)
+	return nil
+}
+
+// convertRepoUpdate returns the repository update for the description,
+// topics and homepage, or nil if the repository has none of them.
+func convertRepoUpdate(repo *types.Repository) *harness.UpdateRepositoryInput {
+	if repo.Description == "" && len(repo.Topics) == 0 && repo.Homepage == "" {
+		return nil
+	}
+
+	in := &harness.UpdateRepositoryInput{}
+	if repo.Description != "" {
+		in.Description = &repo.Description
+	}
+	// topics are kept as value-less tags, the homepage as a tag
+	// since harness repositories have no dedicated field.
+	if len(repo.Topics) > 0 || repo.Homepage != "" {
+		in.Tags = make(map[string]string, len(repo.Topics)+1)
+		for _, topic := range repo.Topics {
+			in.Tags[topic] = ""
+		}
+		if repo.Homepage != "" {
+			in.Tags["homepage"] = repo.Homepage
+		}
+	}
+	return in
+}
+
+// convertMergeSettings returns a rule applying the merge settings
+// to all branches of the repository.
+func convertMergeSettings(merge *types.MergeSettings) *types.BranchRule {
+	return &types.BranchRule{
+		Identifier: mergeSettingsRule,
+		State:      string(enum.RuleStateActive),
+		Definition: types.Definition{
+			PullReq: types.PullReq{
+				Merge: types.Merge{
+					StrategiesAllowed: merge.StrategiesAllowed,
+					DeleteBranch:      merge.DeleteBranch,
+				},
+			},
+		},
+	}
+}
+
 func (m *Importer) getFileSizeLimit(
 	repoRef string,
 	tracer tracer.Tracer,
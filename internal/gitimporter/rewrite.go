@@ -0,0 +1,346 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/harness/harness-migrate/internal/common"
+	"github.com/harness/harness-migrate/internal/report"
+	"github.com/harness/harness-migrate/internal/util"
+	"github.com/harness/harness-migrate/types"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/object"
+)
+
+// IdentityMapper maps the name and email of commit authors,
+// committers and taggers.
+type IdentityMapper interface {
+	MapIdentity(name, email string) (string, string)
+}
+
+// historyRewriter rewrites the identities of commits and annotated tags,
+// and their descendants, whose hashes change with their parents.
+type historyRewriter struct {
+	repo   *git.Repository
+	mapper IdentityMapper
+
+	objects map[plumbing.Hash]plumbing.Hash // rewritten hash by original hash
+}
+
+// RewriteIdentities rewrites the commit identities of the repository with
+// the importer identity mapper before it is pushed, and updates the commit
+// SHAs referenced by its pull requests.
+func (m *Importer) RewriteIdentities(repoRef, repoFolder string) error {
+	m.Tracer.Start(common.MsgStartRewriteIdentities, repoRef)
+
+	shas, err := rewriteRepository(repoFolder, m.Identities)
+	if err != nil {
+		m.Tracer.Stop(common.ErrRewriteIdentities, repoRef, err)
+		return fmt.Errorf(common.ErrRewriteIdentities, repoRef, err)
+	}
+
+	m.Report[repoRef].ReportMetric(report.ReportTypeIdentities, len(shas))
+	if len(shas) != 0 {
+		m.Report[repoRef].ReportDetail(report.ReportTypeIdentities, repoRef,
+			fmt.Sprintf("%d commit and tag SHAs differ from the source repository", len(shas)))
+	}
+	m.Tracer.Stop(common.MsgCompleteRewriteIdentities, len(shas), repoRef)
+	return nil
+}
+
+// rewriteRepository rewrites the history of the exported repository and
+// the commit SHAs referenced by its pull requests, and returns the
+// rewritten SHAs by their original SHA.
+func rewriteRepository(repoFolder string, mapper IdentityMapper) (map[string]string, error) {
+	shas, err := rewriteHistory(filepath.Join(repoFolder, types.GitDir), mapper)
+	if err != nil {
+		return nil, err
+	}
+	if err := rewritePullRequestSHAs(filepath.Join(repoFolder, types.PullRequestDir), shas); err != nil {
+		return nil, err
+	}
+	return shas, nil
+}
+
+// rewriteHistory rewrites all references of the repository and returns
+// the rewritten SHAs by their original SHA.
+func rewriteHistory(gitPath string, mapper IdentityMapper) (map[string]string, error) {
+	repo, err := git.PlainOpen(gitPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the repository %q: %w", gitPath, err)
+	}
+
+	refs, err := repo.References()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list references: %w", err)
+	}
+	var hashRefs []*plumbing.Reference
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() == plumbing.HashReference {
+			hashRefs = append(hashRefs, ref)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list references: %w", err)
+	}
+
+	r := &historyRewriter{
+		repo:    repo,
+		mapper:  mapper,
+		objects: make(map[plumbing.Hash]plumbing.Hash),
+	}
+	for _, ref := range hashRefs {
+		hash, err := r.rewriteObject(ref.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewrite %s: %w", ref.Name(), err)
+		}
+		if hash == ref.Hash() {
+			continue
+		}
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(ref.Name(), hash)); err != nil {
+			return nil, fmt.Errorf("failed to update %s: %w", ref.Name(), err)
+		}
+	}
+
+	shas := make(map[string]string)
+	for from, to := range r.objects {
+		if from != to {
+			shas[from.String()] = to.String()
+		}
+	}
+	return shas, nil
+}
+
+func (r *historyRewriter) rewriteObject(hash plumbing.Hash) (plumbing.Hash, error) {
+	if rewritten, ok := r.objects[hash]; ok {
+		return rewritten, nil
+	}
+
+	obj, err := r.repo.Storer.EncodedObject(plumbing.AnyObject, hash)
+	if err != nil {
+		return hash, err
+	}
+	switch obj.Type() {
+	case plumbing.CommitObject:
+		return r.rewriteCommits(hash)
+	case plumbing.TagObject:
+		return r.rewriteTag(hash)
+	default:
+		return hash, nil
+	}
+}
+
+// rewriteCommits rewrites the commit after its parents, without recursion
+// as histories can be deep.
+func (r *historyRewriter) rewriteCommits(hash plumbing.Hash) (plumbing.Hash, error) {
+	stack := []plumbing.Hash{hash}
+	for len(stack) != 0 {
+		current := stack[len(stack)-1]
+		if _, ok := r.objects[current]; ok {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		commit, err := r.repo.CommitObject(current)
+		if errors.Is(err, plumbing.ErrObjectNotFound) && current != hash {
+			// parents missing from a shallow history are kept.
+			r.objects[current] = current
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		if err != nil {
+			return hash, err
+		}
+
+		pending := false
+		for _, parent := range commit.ParentHashes {
+			if _, ok := r.objects[parent]; !ok {
+				stack = append(stack, parent)
+				pending = true
+			}
+		}
+		if pending {
+			continue
+		}
+
+		stack = stack[:len(stack)-1]
+		rewritten, err := r.writeCommit(commit)
+		if err != nil {
+			return hash, err
+		}
+		r.objects[current] = rewritten
+	}
+	return r.objects[hash], nil
+}
+
+func (r *historyRewriter) writeCommit(commit *object.Commit) (plumbing.Hash, error) {
+	author, authorChanged := r.mapSignature(commit.Author)
+	committer, committerChanged := r.mapSignature(commit.Committer)
+	changed := authorChanged || committerChanged
+
+	parents := make([]plumbing.Hash, len(commit.ParentHashes))
+	for i, parent := range commit.ParentHashes {
+		parents[i] = r.objects[parent]
+		changed = changed || parents[i] != parent
+	}
+	if !changed {
+		return commit.Hash, nil
+	}
+
+	commit.Author = author
+	commit.Committer = committer
+	commit.ParentHashes = parents
+	// the signature is not valid for the rewritten commit.
+	commit.PGPSignature = ""
+
+	obj := r.repo.Storer.NewEncodedObject()
+	if err := commit.Encode(obj); err != nil {
+		return commit.Hash, err
+	}
+	return r.repo.Storer.SetEncodedObject(obj)
+}
+
+func (r *historyRewriter) rewriteTag(hash plumbing.Hash) (plumbing.Hash, error) {
+	tag, err := r.repo.TagObject(hash)
+	if err != nil {
+		return hash, err
+	}
+
+	target, err := r.rewriteObject(tag.Target)
+	if err != nil {
+		return hash, err
+	}
+	tagger, changed := r.mapSignature(tag.Tagger)
+	if !changed && target == tag.Target {
+		r.objects[hash] = hash
+		return hash, nil
+	}
+
+	tag.Target = target
+	tag.Tagger = tagger
+	// the signature is not valid for the rewritten tag.
+	tag.PGPSignature = ""
+
+	obj := r.repo.Storer.NewEncodedObject()
+	if err := tag.Encode(obj); err != nil {
+		return hash, err
+	}
+	rewritten, err := r.repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return hash, err
+	}
+	r.objects[hash] = rewritten
+	return rewritten, nil
+}
+
+func (r *historyRewriter) mapSignature(sig object.Signature) (object.Signature, bool) {
+	name, email := r.mapper.MapIdentity(sig.Name, sig.Email)
+	changed := name != sig.Name || email != sig.Email
+	sig.Name, sig.Email = name, email
+	return sig, changed
+}
+
+// rewritePullRequestSHAs replaces the commit SHAs referenced by the pull
+// requests of the repository.
+func rewritePullRequestSHAs(prDir string, shas map[string]string) error {
+	if len(shas) == 0 {
+		return nil
+	}
+
+	prFiles, err := filepath.Glob(filepath.Join(prDir, "pr[0-9]*.json"))
+	if err != nil {
+		return fmt.Errorf("error listing PR files in %s: %w", prDir, err)
+	}
+
+	remap := newSHAMapper(shas)
+	for _, prFile := range prFiles {
+		data, err := os.ReadFile(prFile)
+		if err != nil {
+			return fmt.Errorf("failed to read %q content: %w", prFile, err)
+		}
+		var prs []*types.PullRequestData
+		if err := json.Unmarshal(data, &prs); err != nil {
+			return fmt.Errorf("error parsing repo pull request json: %w", err)
+		}
+
+		for _, pr := range prs {
+			remap(&pr.PullRequest.SHA)
+			remap(&pr.PullRequest.Merge)
+			remap(&pr.PullRequest.Base.SHA)
+			remap(&pr.PullRequest.Head.SHA)
+			for i := range pr.Comments {
+				if code := pr.Comments[i].CodeComment; code != nil {
+					remap(&code.SourceSHA)
+					remap(&code.MergeBaseSHA)
+				}
+			}
+			for i := range pr.Reviews {
+				remap(&pr.Reviews[i].SHA)
+			}
+			for i := range pr.Activities {
+				remap(&pr.Activities[i].From)
+				remap(&pr.Activities[i].To)
+			}
+		}
+
+		prJson, err := util.GetJson(prs)
+		if err != nil {
+			return fmt.Errorf("cannot serialize pull requests data into json: %w", err)
+		}
+		if err := util.WriteFile(prFile, prJson); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newSHAMapper returns a function replacing a SHA by its rewritten SHA.
+// Abbreviated SHAs are replaced by the full rewritten SHA they prefix.
+func newSHAMapper(shas map[string]string) func(sha *string) {
+	sorted := make([]string, 0, len(shas))
+	for sha := range shas {
+		sorted = append(sorted, sha)
+	}
+	sort.Strings(sorted)
+
+	return func(sha *string) {
+		if *sha == "" {
+			return
+		}
+		if rewritten, ok := shas[*sha]; ok {
+			*sha = rewritten
+			return
+		}
+		if len(*sha) >= 40 {
+			return
+		}
+		prefix := strings.ToLower(*sha)
+		i := sort.SearchStrings(sorted, prefix)
+		if i < len(sorted) && strings.HasPrefix(sorted[i], prefix) &&
+			(i+1 == len(sorted) || !strings.HasPrefix(sorted[i+1], prefix)) {
+			*sha = shas[sorted[i]]
+		}
+	}
+}
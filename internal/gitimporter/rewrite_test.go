@@ -0,0 +1,179 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/harness-migrate/internal/util"
+	"github.com/harness/harness-migrate/types"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/object"
+)
+
+type testIdentities map[string]string
+
+func (m testIdentities) MapIdentity(name, email string) (string, string) {
+	if to, ok := m[email]; ok {
+		return name, to
+	}
+	return name, email
+}
+
+func TestRewriteHistory(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commit := func(email string) plumbing.Hash {
+		if err := os.WriteFile(filepath.Join(dir, "file"), []byte(email+time.Now().String()), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := worktree.Add("file"); err != nil {
+			t.Fatal(err)
+		}
+		sig := &object.Signature{Name: "dev", Email: email, When: time.Now()}
+		hash, err := worktree.Commit("change", &git.CommitOptions{Author: sig, Committer: sig})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hash
+	}
+
+	first := commit("keep@example.com")
+	second := commit("old@example.com")
+	third := commit("keep@example.com")
+
+	sig := &object.Signature{Name: "dev", Email: "old@example.com", When: time.Now()}
+	if _, err := repo.CreateTag("v1", third, &git.CreateTagOptions{Tagger: sig, Message: "v1"}); err != nil {
+		t.Fatal(err)
+	}
+	pullreq := plumbing.NewHashReference("refs/pullreq/1/head", second)
+	if err := repo.Storer.SetReference(pullreq); err != nil {
+		t.Fatal(err)
+	}
+
+	shas, err := rewriteHistory(dir, testIdentities{"old@example.com": "new@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := shas[first.String()]; ok {
+		t.Errorf("Want commit before the rewritten commit to be kept")
+	}
+	if _, ok := shas[second.String()]; !ok {
+		t.Errorf("Want rewritten commit")
+	}
+	if _, ok := shas[third.String()]; !ok {
+		t.Errorf("Want descendant of the rewritten commit to be rewritten")
+	}
+
+	ref, err := repo.Reference("refs/pullreq/1/head", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref.Hash().String() != shas[second.String()] {
+		t.Errorf("Want pull request ref to point to the rewritten commit")
+	}
+	rewritten, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rewritten.Author.Email != "new@example.com" || rewritten.ParentHashes[0] != first {
+		t.Errorf("Unexpected rewritten commit %s with parents %v", rewritten.Author.Email, rewritten.ParentHashes)
+	}
+
+	tagRef, err := repo.Tag("v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag, err := repo.TagObject(tagRef.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.Tagger.Email != "new@example.com" || tag.Target.String() != shas[third.String()] {
+		t.Errorf("Unexpected rewritten tag by %s on %s", tag.Tagger.Email, tag.Target)
+	}
+
+	// pull requests reference the rewritten commits, including abbreviated SHAs.
+	prDir := filepath.Join(t.TempDir(), types.PullRequestDir)
+	if err := util.CreateFolder(prDir); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := util.GetJson([]*types.PullRequestData{{
+		PullRequest: types.PullRequest{SHA: second.String(), Base: types.Reference{SHA: first.String()}},
+		Reviews:     []types.Review{{SHA: second.String()[:12]}},
+	}})
+	if err := util.WriteFile(filepath.Join(prDir, "pr0.json"), data); err != nil {
+		t.Fatal(err)
+	}
+	if err := rewritePullRequestSHAs(prDir, shas); err != nil {
+		t.Fatal(err)
+	}
+	prs, err := new(Importer).readPRs(prDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prs[0].PullRequest.SHA != shas[second.String()] || prs[0].PullRequest.Base.SHA != first.String() ||
+		prs[0].Reviews[0].SHA != shas[second.String()] {
+		t.Errorf("Unexpected pull request SHAs %+v", prs[0])
+	}
+}
+
+func TestMailmap(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".mailmap")
+	mailmap := strings.Join([]string{
+		"# comment",
+		"Jane Doe <jane@old.com>",
+		"<john@acme.com> <john@old.com>",
+		"Team Bot <bot@acme.com> Deploy Bot <bot@old.com> # trailing comment",
+	}, "\n")
+	if err := os.WriteFile(file, []byte(mailmap), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := LoadMailmap(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name, email, wantName, wantEmail string
+	}{
+		{"jane", "JANE@old.com", "Jane Doe", "JANE@old.com"},
+		{"John", "john@old.com", "John", "john@acme.com"},
+		{"Deploy Bot", "bot@old.com", "Team Bot", "bot@acme.com"},
+		{"Other Bot", "bot@old.com", "Other Bot", "bot@old.com"},
+	}
+	for _, test := range tests {
+		name, email := m.MapIdentity(test.name, test.email)
+		if name != test.wantName || email != test.wantEmail {
+			t.Errorf("Want %s <%s> for %s <%s>, got %s <%s>",
+				test.wantName, test.wantEmail, test.name, test.email, name, email)
+		}
+	}
+}
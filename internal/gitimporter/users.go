@@ -16,7 +16,11 @@ package gitimporter
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/harness/harness-migrate/internal/common"
+	"github.com/harness/harness-migrate/internal/gitexporter"
+	"github.com/harness/harness-migrate/internal/report"
 	"github.com/harness/harness-migrate/types"
 )
 
@@ -27,3 +31,54 @@ func (m *Importer) CheckUsers(users []string) ([]string, error) {
 	}
 	return unknownUsers.UnknownEmails, nil
 }
+
+// InviteUsers invites the users missing on the target. Users which are not
+// invited are listed in the report and their activities are attributed to
+// the token owner, as with skipped users.
+func (m *Importer) InviteUsers(emails []string) {
+	m.Tracer.Start(common.MsgStartInviteUsers, len(emails))
+
+	if m.Report[m.HarnessSpace] == nil {
+		m.Report[m.HarnessSpace] = report.Init(m.HarnessSpace)
+	}
+	r := m.Report[m.HarnessSpace]
+
+	invited := 0
+	for _, email := range emails {
+		if strings.HasSuffix(email, gitexporter.UnknownEmailSuffix) {
+			r.ReportError(report.ReportTypeUsers, email, "not invited: the email of the user is unknown")
+			continue
+		}
+		if !m.domainAllowed(email) {
+			r.ReportError(report.ReportTypeUsers, email, "not invited: the email domain is not allowed")
+			continue
+		}
+
+		status, err := m.Harness.InviteUser(email)
+		if err != nil {
+			m.Tracer.LogError(common.ErrInviteUser, email, err)
+			r.ReportError(report.ReportTypeUsers, email, err.Error())
+			continue
+		}
+		r.ReportDetail(report.ReportTypeUsers, email, status)
+		invited++
+	}
+
+	r.ReportMetric(report.ReportTypeUsers, invited)
+	m.Tracer.Stop(common.MsgCompleteInviteUsers, invited, len(emails)-invited)
+}
+
+// domainAllowed returns true if users of the email domain can be invited.
+func (m *Importer) domainAllowed(email string) bool {
+	if len(m.flags.InviteDomains) == 0 {
+		return true
+	}
+
+	_, domain, _ := strings.Cut(email, "@")
+	for _, allowed := range m.flags.InviteDomains {
+		if strings.EqualFold(domain, strings.TrimPrefix(allowed, "@")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/harness/harness-migrate/internal/harness"
+	"github.com/harness/harness-migrate/internal/report"
+	"github.com/harness/harness-migrate/internal/tracer"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type inviteClient struct {
+	harness.Client
+	invited []string
+}
+
+func (c *inviteClient) InviteUser(email string) (string, error) {
+	if email == "fail@acme.com" {
+		return "", errors.New("invite failed")
+	}
+	c.invited = append(c.invited, email)
+	return "USER_INVITED_SUCCESSFULLY", nil
+}
+
+func TestInviteUsers(t *testing.T) {
+	client := new(inviteClient)
+	importer := &Importer{
+		Harness:      client,
+		HarnessSpace: "acc/org",
+		Tracer:       tracer.Default(),
+		Report:       map[string]*report.Report{},
+		flags:        Flags{InviteDomains: []string{"@acme.com", "Example.org"}},
+	}
+
+	importer.InviteUsers([]string{
+		"jane@acme.com",
+		"john@example.ORG",
+		"eve@other.com",
+		"fail@acme.com",
+		"octocat@unknownemail.harness.io",
+	})
+
+	want := []string{"jane@acme.com", "john@example.ORG"}
+	if diff := cmp.Diff(want, client.invited); diff != "" {
+		t.Errorf("unexpected invited users")
+		t.Log(diff)
+	}
+	if importer.Report["acc/org"] == nil {
+		t.Errorf("expect invites to be reported on the space")
+	}
+}
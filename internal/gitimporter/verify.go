@@ -0,0 +1,495 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/harness/harness-migrate/internal/harness"
+	"github.com/harness/harness-migrate/internal/util"
+	"github.com/harness/harness-migrate/types"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing"
+)
+
+// verifyPageSize is the page size used to list the target resources.
+const verifyPageSize = 100
+
+// Verification is the result of comparing a repository in the
+// archive with the imported repository. Repositories skipped on
+// import are not verified.
+type Verification struct {
+	Source  string   `json:"source"`
+	Target  string   `json:"target,omitempty"`
+	Passed  bool     `json:"passed"`
+	Skipped bool     `json:"skipped,omitempty"`
+	Diffs   []string `json:"diffs,omitempty"`
+}
+
+// Verify compares each repository in the archive with its imported
+// target: branches and tags, the default branch, pull requests and
+// their comments, labels, webhooks and rules. Metadata skipped with
+// the import flags is not verified. Histories rewritten on import are
+// compared after rewriting the archive with the same identities.
+//
+// The targets recorded on import are used when set. Otherwise they
+// are resolved again with the mapping and the collision policy,
+// which only knows the collisions within the archive.
+func (m *Importer) Verify(ctx context.Context) ([]*Verification, error) {
+	unzipLocation, err := os.MkdirTemp("", "harness-verify-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(unzipLocation)
+
+	if err := util.Unzip(m.ZipFileLocation, unzipLocation); err != nil {
+		return nil, fmt.Errorf("error unzipping: %w", err)
+	}
+
+	folders, err := getRepoBaseFolders(unzipLocation, m.HarnessRepo)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get repo folders in unzip: %w", err)
+	}
+
+	if m.Targets == nil {
+		if _, err := m.resolveTargets(folders, false); err != nil {
+			return nil, err
+		}
+	}
+	resolved := make(map[string]*Target, len(m.Targets))
+	for _, target := range m.Targets {
+		resolved[target.Source] = target
+	}
+
+	var results []*Verification
+	for _, f := range folders {
+		repository, err := m.ReadRepoInfo(f)
+		if errors.Is(err, ErrInvalidRepoDir) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read repo info from %q: %w", f, err)
+		}
+
+		target, ok := resolved[repository.Slug]
+		if !ok || target.Skipped {
+			results = append(results, &Verification{
+				Source:  repository.Slug,
+				Skipped: true,
+			})
+			continue
+		}
+		repoRef := target.Target
+
+		m.Tracer.Start("verify repository %s", repoRef)
+		diffs, err := m.verifyRepo(ctx, repoRef, f, &repository)
+		if err != nil {
+			m.Tracer.Stop("verify repository %s [failed]", repoRef)
+			if notRecoverableError(err) && !errors.Is(err, harness.ErrNotFound) {
+				return nil, err
+			}
+			diffs = append(diffs, err.Error())
+		} else {
+			m.Tracer.Stop("verify repository %s [done]", repoRef)
+		}
+
+		results = append(results, &Verification{
+			Source: repository.Slug,
+			Target: repoRef,
+			Passed: len(diffs) == 0,
+			Diffs:  diffs,
+		})
+	}
+	return results, nil
+}
+
+func (m *Importer) verifyRepo(
+	_ context.Context,
+	repoRef string,
+	repoFolder string,
+	repository *types.Repository,
+) ([]string, error) {
+	target, err := m.Harness.GetRepository(repoRef)
+	if errors.Is(err, harness.ErrNotFound) {
+		return []string{"repository: not found on target"}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	var diffs []string
+	if !repository.IsEmpty && target.DefaultBranch != repository.Branch {
+		diffs = append(diffs, fmt.Sprintf("default branch: source %q, target %q",
+			repository.Branch, target.DefaultBranch))
+	}
+
+	// the imported history was rewritten with the identities,
+	// rewrite the archive the same way to compare the SHAs.
+	if m.Identities != nil && !repository.IsEmpty {
+		if _, err := rewriteRepository(repoFolder, m.Identities); err != nil {
+			return nil, fmt.Errorf("failed to rewrite identities: %w", err)
+		}
+	}
+
+	if !repository.IsEmpty {
+		refDiffs, err := m.verifyRefs(repoRef, repoFolder)
+		if err != nil {
+			return diffs, err
+		}
+		diffs = append(diffs, refDiffs...)
+	}
+
+	if !m.flags.NoPR {
+		prDiffs, err := m.verifyPullRequests(repoRef, repoFolder)
+		if err != nil {
+			return diffs, err
+		}
+		diffs = append(diffs, prDiffs...)
+	}
+
+	if !m.flags.NoLabel {
+		labelDiffs, err := m.verifyLabels(repoRef, repoFolder)
+		if err != nil {
+			return diffs, err
+		}
+		diffs = append(diffs, labelDiffs...)
+	}
+
+	if !m.flags.NoWebhook {
+		hookDiffs, err := m.verifyWebhooks(repoRef, repoFolder)
+		if err != nil {
+			return diffs, err
+		}
+		diffs = append(diffs, hookDiffs...)
+	}
+
+	if !m.flags.NoRule {
+		ruleDiffs, err := m.verifyRules(repoRef, repoFolder)
+		if err != nil {
+			return diffs, err
+		}
+		diffs = append(diffs, ruleDiffs...)
+	}
+
+	return diffs, nil
+}
+
+// verifyRefs compares the branches and tags of the exported
+// git repository with the target.
+func (m *Importer) verifyRefs(repoRef, repoFolder string) ([]string, error) {
+	branches, tags, err := readRefs(filepath.Join(repoFolder, types.GitDir))
+	if err != nil {
+		return nil, err
+	}
+
+	targetBranches, err := listAll(func(page int) ([]*harness.Reference, error) {
+		return m.Harness.ListBranches(repoRef, page, verifyPageSize)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+	targetTags, err := listAll(func(page int) ([]*harness.Reference, error) {
+		return m.Harness.ListTags(repoRef, page, verifyPageSize)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	diffs := diffRefs("branch", branches, refMap(targetBranches))
+	return append(diffs, diffRefs("tag", tags, refMap(targetTags))...), nil
+}
+
+// verifyPullRequests compares the pull requests, their states and
+// the number of comments with the target.
+func (m *Importer) verifyPullRequests(repoRef, repoFolder string) ([]string, error) {
+	prs, err := m.readPRs(filepath.Join(repoFolder, types.PullRequestDir))
+	if err != nil {
+		return nil, err
+	}
+
+	targetPRs, err := listAll(func(page int) ([]*harness.PullReq, error) {
+		return m.Harness.ListPullRequests(repoRef, page, verifyPageSize)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+
+	var diffs []string
+	if len(prs) != len(targetPRs) {
+		diffs = append(diffs, fmt.Sprintf("pull requests: source %d, target %d", len(prs), len(targetPRs)))
+	}
+
+	states := make(map[int]string, len(targetPRs))
+	for _, pr := range targetPRs {
+		states[pr.Number] = pr.State
+	}
+
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].PullRequest.Number < prs[j].PullRequest.Number
+	})
+	for _, pr := range prs {
+		number := pr.PullRequest.Number
+		state, ok := states[number]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("pull request #%d: missing on target", number))
+			continue
+		}
+		if want := pullRequestState(&pr.PullRequest); state != want {
+			diffs = append(diffs, fmt.Sprintf("pull request #%d: state source %q, target %q", number, want, state))
+		}
+
+		activities, err := m.Harness.ListPullRequestActivities(repoRef, number)
+		if err != nil {
+			return diffs, fmt.Errorf("failed to list activities of pull request %d: %w", number, err)
+		}
+		// activities are imported as comments, they are told
+		// apart from the source comments by their body.
+		var bodies []string
+		if !m.flags.NoActivity {
+			for _, activity := range pr.Activities {
+				bodies = append(bodies, renderActivity(activity))
+			}
+		}
+		comments, activityComments := countComments(activities, bodies)
+		if comments != len(pr.Comments) {
+			diffs = append(diffs, fmt.Sprintf("pull request #%d: comments source %d, target %d",
+				number, len(pr.Comments), comments))
+		}
+		if activityComments != len(bodies) {
+			diffs = append(diffs, fmt.Sprintf("pull request #%d: activities source %d, target %d",
+				number, len(bodies), activityComments))
+		}
+	}
+	return diffs, nil
+}
+
+// verifyLabels checks the repository and namespace labels exist
+// on the target, as repository or inherited labels.
+func (m *Importer) verifyLabels(repoRef, repoFolder string) ([]string, error) {
+	labels, err := m.readLabels(repoFolder)
+	if err != nil {
+		return nil, err
+	}
+	spaceLabels, err := m.readLabels(filepath.Dir(repoFolder))
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, label := range append(labels, spaceLabels...) {
+		keys = append(keys, strings.ToLower(label.Name))
+	}
+
+	targetLabels, err := listAll(func(page int) ([]*harness.Label, error) {
+		return m.Harness.ListLabels(repoRef, page, verifyPageSize)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	targetKeys := make(map[string]bool, len(targetLabels))
+	for _, label := range targetLabels {
+		targetKeys[strings.ToLower(label.Key)] = true
+	}
+	return diffMissing("label", keys, targetKeys), nil
+}
+
+// verifyWebhooks checks the webhooks exist on the target.
+func (m *Importer) verifyWebhooks(repoRef, repoFolder string) ([]string, error) {
+	hooks, err := m.readWebhooks(repoFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	var identifiers []string
+	if hooks != nil {
+		for _, hook := range hooks.Hooks {
+			identifiers = append(identifiers, hook.Identifier)
+		}
+	}
+
+	targetHooks, err := listAll(func(page int) ([]*harness.Webhook, error) {
+		return m.Harness.ListWebhooks(repoRef, page, verifyPageSize)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	targetIdentifiers := make(map[string]bool, len(targetHooks))
+	for _, hook := range targetHooks {
+		targetIdentifiers[hook.Identifier] = true
+	}
+	return diffMissing("webhook", identifiers, targetIdentifiers), nil
+}
+
+// verifyRules checks the branch rules exist on the target.
+func (m *Importer) verifyRules(repoRef, repoFolder string) ([]string, error) {
+	rules, err := m.readBranchRules(repoFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	var identifiers []string
+	for _, rule := range rules {
+		identifiers = append(identifiers, rule.Identifier)
+	}
+
+	targetRules, err := listAll(func(page int) ([]*harness.Rule, error) {
+		return m.Harness.ListRules(repoRef, page, verifyPageSize)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rules: %w", err)
+	}
+	targetIdentifiers := make(map[string]bool, len(targetRules))
+	for _, rule := range targetRules {
+		targetIdentifiers[rule.Identifier] = true
+	}
+	return diffMissing("rule", identifiers, targetIdentifiers), nil
+}
+
+// readRefs returns the branches and tags of the exported
+// git repository, keyed by name.
+func readRefs(gitPath string) (map[string]string, map[string]string, error) {
+	repo, err := git.PlainOpen(gitPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open git repository %q: %w", gitPath, err)
+	}
+	refs, err := repo.References()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list git references: %w", err)
+	}
+
+	branches := make(map[string]string)
+	tags := make(map[string]string)
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		switch {
+		case ref.Name().IsBranch():
+			branches[ref.Name().Short()] = ref.Hash().String()
+		case ref.Name().IsTag():
+			tags[ref.Name().Short()] = ref.Hash().String()
+		}
+		return nil
+	})
+	return branches, tags, err
+}
+
+// listAll lists all pages of a target resource.
+func listAll[T any](list func(page int) ([]T, error)) ([]T, error) {
+	var out []T
+	for page := 1; ; page++ {
+		items, err := list(page)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, items...)
+		if len(items) < verifyPageSize {
+			return out, nil
+		}
+	}
+}
+
+// helper function returns the references keyed by name.
+func refMap(refs []*harness.Reference) map[string]string {
+	out := make(map[string]string, len(refs))
+	for _, ref := range refs {
+		out[ref.Name] = ref.SHA
+	}
+	return out
+}
+
+// diffRefs returns the missing, unexpected and mismatching
+// references of the target, sorted by name.
+func diffRefs(kind string, source, target map[string]string) []string {
+	var diffs []string
+	for name, sha := range source {
+		targetSHA, ok := target[name]
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("%s %s: missing on target", kind, name))
+		case targetSHA != sha:
+			diffs = append(diffs, fmt.Sprintf("%s %s: sha source %s, target %s", kind, name, sha, targetSHA))
+		}
+	}
+	for name := range target {
+		if _, ok := source[name]; !ok {
+			diffs = append(diffs, fmt.Sprintf("%s %s: not in source", kind, name))
+		}
+	}
+	sort.Strings(diffs)
+	return diffs
+}
+
+// diffMissing returns the source identifiers missing on the target.
+func diffMissing(kind string, source []string, target map[string]bool) []string {
+	var diffs []string
+	seen := make(map[string]bool, len(source))
+	for _, id := range source {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if !target[id] {
+			diffs = append(diffs, fmt.Sprintf("%s %s: missing on target", kind, id))
+		}
+	}
+	sort.Strings(diffs)
+	return diffs
+}
+
+// helper function returns the harness state of the pull request.
+func pullRequestState(pr *types.PullRequest) string {
+	switch {
+	case pr.Merged:
+		return "merged"
+	case pr.Closed:
+		return "closed"
+	default:
+		return "open"
+	}
+}
+
+// helper function counts the comments that are not deleted, and
+// separately the comments matching the bodies of the activities.
+func countComments(activities []*harness.PullReqActivity, bodies []string) (int, int) {
+	remaining := make(map[string]int, len(bodies))
+	for _, body := range bodies {
+		remaining[body]++
+	}
+
+	var comments, activityComments int
+	for _, activity := range activities {
+		if activity.Deleted != nil {
+			continue
+		}
+		if activity.Kind != "comment" && activity.Kind != "change-comment" {
+			continue
+		}
+		if remaining[activity.Text] > 0 {
+			remaining[activity.Text]--
+			activityComments++
+			continue
+		}
+		comments++
+	}
+	return comments, activityComments
+}
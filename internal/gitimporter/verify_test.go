@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitimporter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/harness-migrate/internal/harness"
+)
+
+func TestDiffRefs(t *testing.T) {
+	source := map[string]string{"main": "a1", "dev": "b2", "feature": "c3"}
+	target := map[string]string{"main": "a1", "dev": "ff", "stale": "d4"}
+
+	want := []string{
+		"branch dev: sha source b2, target ff",
+		"branch feature: missing on target",
+		"branch stale: not in source",
+	}
+	if got := diffRefs("branch", source, target); !reflect.DeepEqual(got, want) {
+		t.Errorf("want diffs %v, got %v", want, got)
+	}
+	if got := diffRefs("tag", source, source); len(got) != 0 {
+		t.Errorf("want no diffs, got %v", got)
+	}
+}
+
+func TestDiffMissing(t *testing.T) {
+	got := diffMissing("label", []string{"bug", "bug", "scope"}, map[string]bool{"bug": true})
+	want := []string{"label scope: missing on target"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want diffs %v, got %v", want, got)
+	}
+}
+
+func TestCountComments(t *testing.T) {
+	deleted := int64(1)
+	activities := []*harness.PullReqActivity{
+		{Kind: "comment", Text: "lgtm"},
+		{Kind: "change-comment", Text: "nit"},
+		{Kind: "comment", Text: "_@jane closed this pull request_"},
+		{Kind: "comment", Text: "_@jane closed this pull request_"},
+		{Kind: "comment", Deleted: &deleted},
+		{Kind: "system"},
+	}
+	comments, activityComments := countComments(activities, []string{"_@jane closed this pull request_"})
+	if comments != 3 || activityComments != 1 {
+		t.Errorf("want 3 comments and 1 activity, got %d and %d", comments, activityComments)
+	}
+}
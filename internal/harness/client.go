@@ -62,6 +62,10 @@ type Client interface {
 	// CreateSecretOrg creates an organization secret.
 	CreateSecretOrg(secret *Secret) error
 
+	// CreateVariable creates an organization or project
+	// variable.
+	CreateVariable(variable *Variable) error
+
 	// CreateConnector creates a connector.
 	CreateConnector(connector *Connector) error
 
@@ -73,6 +77,11 @@ type Client interface {
 	// given identifier and name.
 	CreatePipeline(org, project string, pipeline []byte) error
 
+	// CreateRemotePipeline creates a pipeline for the
+	// organization and project, committing the yaml to
+	// the git repository of the store.
+	CreateRemotePipeline(org, project string, pipeline []byte, store *GitStore) error
+
 	// CreateRepository creates a repository.
 	CreateRepository(parentRef string, repo *CreateRepositoryInput) (*Repository, error)
 
@@ -88,6 +97,12 @@ type Client interface {
 	// UpdateRepoSettings updates general settings of a repository.
 	UpdateRepoSettings(repoRef string, in *RepoSettings) (*RepoSettings, error)
 
+	// UpdateRepository updates the description and tags of a repository.
+	UpdateRepository(repoRef string, in *UpdateRepositoryInput) (*Repository, error)
+
+	// ArchiveRepository switches a repository to the read-only archived state.
+	ArchiveRepository(repoRef string) error
+
 	// UpdateRepositoryState updates a repository state (for different steps of the migration).
 	UpdateRepositoryState(repoRef string, in *UpdateRepositoryStateInput) (*Repository, error)
 
@@ -103,11 +118,43 @@ type Client interface {
 	// ImportLabels imports labels of a repository or space.
 	ImportLabels(parentRef string, in *types.LabelsInput) error
 
+	// ImportSpaceLabels imports labels of a space, which are
+	// inherited by all repositories of the space.
+	ImportSpaceLabels(spaceRef string, in *types.LabelsInput) error
+
 	// CheckUsers provides all email id to harness code of users which needs to be checked for existence.
 	CheckUsers(in *types.CheckUsersInput) (*types.CheckUsersOutput, error)
 
 	// GetRepository returns metadata about a repository for incremental migration.
 	GetRepository(repoRef string) (*Repository, error)
+
+	// ListBranches returns a page of branches of a repository.
+	ListBranches(repoRef string, page, limit int) ([]*Reference, error)
+
+	// ListTags returns a page of tags of a repository.
+	ListTags(repoRef string, page, limit int) ([]*Reference, error)
+
+	// ListPullRequests returns a page of pull requests of a repository in any state.
+	ListPullRequests(repoRef string, page, limit int) ([]*PullReq, error)
+
+	// ListPullRequestActivities returns the activities of a pull request.
+	ListPullRequestActivities(repoRef string, number int) ([]*PullReqActivity, error)
+
+	// ListLabels returns a page of labels of a repository, including inherited labels.
+	ListLabels(repoRef string, page, limit int) ([]*Label, error)
+
+	// ListWebhooks returns a page of webhooks of a repository.
+	ListWebhooks(repoRef string, page, limit int) ([]*Webhook, error)
+
+	// ListRules returns a page of protection rules of a repository.
+	ListRules(repoRef string, page, limit int) ([]*Rule, error)
+
+	// UploadAttachment uploads a file to a repository and returns its url.
+	UploadAttachment(repoRef string, data []byte) (string, error)
+
+	// InviteUser invites the user to the account, or creates the user
+	// on Gitness, and returns the result reported by the server.
+	InviteUser(email string) (string, error)
 }
 
 // WaitHarnessSecretManager blocks until the harness
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"path"
 	"strings"
 
@@ -191,6 +192,21 @@ func (c *client) CreateSecretOrg(secret *Secret) error {
 	return nil
 }
 
+// CreateVariable creates an organization or project
+// variable.
+func (c *client) CreateVariable(variable *Variable) error {
+	in := new(variableCreateEnvelope)
+	in.Variable = variable
+	out := new(variableEnvelope)
+	uri := fmt.Sprintf("%s/gateway/ng/api/variables?accountIdentifier=%s&orgIdentifier=%s&projectIdentifier=%s",
+		c.address,
+		c.account,
+		variable.Orgidentifier,
+		variable.Projectidentifier,
+	)
+	return c.post(uri, in, out)
+}
+
 // CreateConnector creates a connector.
 func (c *client) CreateConnector(connector *Connector) error {
 	in := new(connectorCreateEnvelope)
@@ -239,6 +255,30 @@ func (c *client) CreatePipeline(org, project string, pipeline []byte) error {
 	return c.post(uri, buf, out)
 }
 
+// CreateRemotePipeline creates a pipeline for the
+// organization and project, committing the yaml to
+// the git repository of the store.
+func (c *client) CreateRemotePipeline(org, project string, pipeline []byte, store *GitStore) error {
+	buf := bytes.NewBuffer(pipeline)
+	out := new(pipelineEnvelope)
+	params := url.Values{}
+	params.Set("accountIdentifier", c.account)
+	params.Set("orgIdentifier", org)
+	params.Set("projectIdentifier", project)
+	params.Set("storeType", "REMOTE")
+	params.Set("connectorRef", store.ConnectorRef)
+	params.Set("repoName", store.RepoName)
+	params.Set("branch", store.Branch)
+	params.Set("filePath", store.FilePath)
+	params.Set("commitMsg", store.CommitMsg)
+	params.Set("isNewBranch", "false")
+	uri := fmt.Sprintf("%s/gateway/pipeline/api/pipelines/v2?%s",
+		c.address,
+		params.Encode(),
+	)
+	return c.post(uri, buf, out)
+}
+
 // CreateRepository creates a repository for the parentRef, if none provide repo will be at the acc level
 func (c *client) CreateRepository(parentRef string, repo *CreateRepositoryInput) (*Repository, error) {
 	out := new(Repository)
@@ -340,6 +380,39 @@ func (c *client) CreateRepositoryForMigration(in *CreateRepositoryForMigrateInpu
 	return out, nil
 }
 
+func (c *client) UpdateRepository(repoRef string, in *UpdateRepositoryInput) (*Repository, error) {
+	out := new(Repository)
+	queryParams, repoPath, err := getQueryParamsFromRepoRef(repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := fmt.Sprintf("%s/gateway/code/api/v1/repos/%s?%s",
+		c.address,
+		repoPath,
+		queryParams,
+	)
+
+	if err := c.patch(uri, in, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *client) ArchiveRepository(repoRef string) error {
+	queryParams, repoPath, err := getQueryParamsFromRepoRef(repoRef)
+	if err != nil {
+		return err
+	}
+
+	uri := fmt.Sprintf("%s/gateway/code/api/v1/repos/%s/archive?%s",
+		c.address,
+		repoPath,
+		queryParams,
+	)
+	return c.post(uri, nil, nil)
+}
+
 func (c *client) UpdateRepositoryState(repoRef string, in *UpdateRepositoryStateInput) (*Repository, error) {
 	out := new(Repository)
 	queryParams, repoPath, err := getQueryParamsFromRepoRef(repoRef)
@@ -430,6 +503,20 @@ func (c *client) ImportLabels(repoRef string, in *types.LabelsInput) error {
 	return nil
 }
 
+func (c *client) ImportSpaceLabels(spaceRef string, in *types.LabelsInput) error {
+	queryParams, spaceRef, err := getQueryParamsFromSpaceRef(spaceRef)
+	if err != nil {
+		return err
+	}
+
+	uri := fmt.Sprintf("%s/gateway/code/api/v1/migrate/spaces/%s/labels?%s",
+		c.address,
+		spaceRef,
+		queryParams,
+	)
+	return c.post(uri, in, nil)
+}
+
 func (c *client) CheckUsers(in *types.CheckUsersInput) (*types.CheckUsersOutput, error) {
 	out := new(types.CheckUsersOutput)
 	uri := fmt.Sprintf("%s/gateway/code/api/v1/principals/check-emails?routingId=%s&accountIdentifier=%s", c.address, c.account, c.account)
@@ -440,6 +527,28 @@ func (c *client) CheckUsers(in *types.CheckUsersInput) (*types.CheckUsersOutput,
 	return out, nil
 }
 
+func (c *client) InviteUser(email string) (string, error) {
+	in := &userInviteEnvelope{
+		Emails:       []string{email},
+		UserGroups:   []string{},
+		RoleBindings: []interface{}{},
+	}
+	out := new(userInviteResponseEnvelope)
+	uri := fmt.Sprintf("%s/gateway/ng/api/user/users?accountIdentifier=%s", c.address, c.account)
+	if err := c.post(uri, in, out); err != nil {
+		return "", err
+	}
+	if out.Data == nil {
+		return "", errors.New("empty invite response")
+	}
+
+	status := out.Data.AddUserResponseMap[email]
+	if status == "" || status == "FAIL" {
+		return status, fmt.Errorf("invite of %s failed", email)
+	}
+	return status, nil
+}
+
 // GetRepository returns metadata about a repository.
 func (c *client) GetRepository(repoRef string) (*Repository, error) {
 	queryParams, repoPath, err := getQueryParamsFromRepoRef(repoRef)
@@ -460,6 +569,93 @@ func (c *client) GetRepository(repoRef string) (*Repository, error) {
 	return out, nil
 }
 
+// ListBranches returns a page of branches of a repository.
+func (c *client) ListBranches(repoRef string, page, limit int) ([]*Reference, error) {
+	var out []*Reference
+	err := c.list(repoRef, "branches", fmt.Sprintf("page=%d&limit=%d", page, limit), &out)
+	return out, err
+}
+
+// ListTags returns a page of tags of a repository.
+func (c *client) ListTags(repoRef string, page, limit int) ([]*Reference, error) {
+	var out []*Reference
+	err := c.list(repoRef, "tags", fmt.Sprintf("page=%d&limit=%d", page, limit), &out)
+	return out, err
+}
+
+// ListPullRequests returns a page of pull requests of a repository in any state.
+func (c *client) ListPullRequests(repoRef string, page, limit int) ([]*PullReq, error) {
+	var out []*PullReq
+	err := c.list(repoRef, "pullreq", fmt.Sprintf("state=open&state=closed&state=merged&page=%d&limit=%d", page, limit), &out)
+	return out, err
+}
+
+// ListPullRequestActivities returns the activities of a pull request.
+func (c *client) ListPullRequestActivities(repoRef string, number int) ([]*PullReqActivity, error) {
+	var out []*PullReqActivity
+	err := c.list(repoRef, fmt.Sprintf("pullreq/%d/activities", number), "", &out)
+	return out, err
+}
+
+// ListLabels returns a page of labels of a repository, including inherited labels.
+func (c *client) ListLabels(repoRef string, page, limit int) ([]*Label, error) {
+	var out []*Label
+	err := c.list(repoRef, "labels", fmt.Sprintf("inherited=true&page=%d&limit=%d", page, limit), &out)
+	return out, err
+}
+
+// ListWebhooks returns a page of webhooks of a repository.
+func (c *client) ListWebhooks(repoRef string, page, limit int) ([]*Webhook, error) {
+	var out []*Webhook
+	err := c.list(repoRef, "webhooks", fmt.Sprintf("page=%d&limit=%d", page, limit), &out)
+	return out, err
+}
+
+// ListRules returns a page of protection rules of a repository.
+func (c *client) ListRules(repoRef string, page, limit int) ([]*Rule, error) {
+	var out []*Rule
+	err := c.list(repoRef, "rules", fmt.Sprintf("page=%d&limit=%d", page, limit), &out)
+	return out, err
+}
+
+// UploadAttachment uploads a file to a repository and returns its url.
+func (c *client) UploadAttachment(repoRef string, data []byte) (string, error) {
+	queryParams, repoPath, err := getQueryParamsFromRepoRef(repoRef)
+	if err != nil {
+		return "", err
+	}
+
+	uri := fmt.Sprintf("%s/gateway/code/api/v1/repos/%s/uploads",
+		c.address,
+		repoPath,
+	)
+
+	out := new(Upload)
+	if err := c.post(uri+"?"+queryParams, bytes.NewBuffer(data), out); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s?%s", uri, out.FilePath, queryParams), nil
+}
+
+// helper function lists a sub resource of a repository.
+func (c *client) list(repoRef, resource, params string, out interface{}) error {
+	queryParams, repoPath, err := getQueryParamsFromRepoRef(repoRef)
+	if err != nil {
+		return err
+	}
+	if params != "" {
+		queryParams = queryParams + "&" + params
+	}
+
+	uri := fmt.Sprintf("%s/gateway/code/api/v1/repos/%s/%s?%s",
+		c.address,
+		repoPath,
+		resource,
+		queryParams,
+	)
+	return c.get(uri, out)
+}
+
 // http request helper functions
 func (c *client) setAuthHeader() func(h *http.Header) {
 	return func(h *http.Header) { h.Set("x-api-key", c.token) }
@@ -253,3 +253,62 @@ func TestCreateError(t *testing.T) {
 		t.Errorf("Expect error %s, got %s", want, got)
 	}
 }
+
+func TestCreateRemotePipeline(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://app.harness.io").
+		Post("/gateway/pipeline/api/pipelines/v2").
+		MatchParam("accountIdentifier", "gVcEoNyqQNKbigC_hA3JqA").
+		MatchParam("orgIdentifier", "default").
+		MatchParam("projectIdentifier", "playground").
+		MatchParam("storeType", "REMOTE").
+		MatchParam("connectorRef", "org.github").
+		MatchParam("repoName", "octocat/hello-world").
+		MatchParam("branch", "main").
+		MatchParam("filePath", ".harness/hello-world.yaml").
+		Reply(200).
+		JSON(map[string]string{"status": "SUCCESS"})
+
+	client := New("gVcEoNyqQNKbigC_hA3JqA", "dummy0d0ac576df34be6a882")
+	err := client.CreateRemotePipeline("default", "playground", []byte("pipeline: {}"), &GitStore{
+		ConnectorRef: "org.github",
+		RepoName:     "octocat/hello-world",
+		Branch:       "main",
+		FilePath:     ".harness/hello-world.yaml",
+		CommitMsg:    "Add pipeline hello-world",
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect remote pipeline request")
+	}
+}
+
+func TestInviteUser(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://app.harness.io").
+		Post("/gateway/ng/api/user/users").
+		MatchParam("accountIdentifier", "gVcEoNyqQNKbigC_hA3JqA").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"status": "SUCCESS",
+			"data": map[string]interface{}{
+				"addUserResponseMap": map[string]string{
+					"jane@acme.com": "USER_INVITED_SUCCESSFULLY",
+				},
+			},
+		})
+
+	client := New("gVcEoNyqQNKbigC_hA3JqA", "dummy0d0ac576df34be6a882")
+	got, err := client.InviteUser("jane@acme.com")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if want := "USER_INVITED_SUCCESSFULLY"; got != want {
+		t.Errorf("want status %s, got %s", want, got)
+	}
+}
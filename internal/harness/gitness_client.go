@@ -15,13 +15,23 @@
 package harness
 
 import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
+	"path"
+	"regexp"
 	"strings"
 
 	"github.com/harness/harness-migrate/types"
 )
 
+// invalidUIDChars matches the characters which are not
+// allowed in the uid of a Gitness user.
+var invalidUIDChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
 type gitnessClient struct {
 	address string
 	token   string
@@ -43,13 +53,30 @@ func NewGitness(token, address string, opts ...Option) Client {
 
 // FindOrg returns an organization by identifier.
 func (c *gitnessClient) FindOrg(org string) (*Org, error) {
-	return nil, fmt.Errorf("not implemented")
+	space, err := c.findSpace(org)
+	if err != nil {
+		return nil, err
+	}
+	return &Org{
+		ID:   space.Identifier,
+		Name: space.Identifier,
+		Desc: space.Description,
+	}, nil
 }
 
 // FindProject returns a project by organization and
 // identifier.
 func (c *gitnessClient) FindProject(org, project string) (*Project, error) {
-	return nil, fmt.Errorf("not implemented")
+	space, err := c.findSpace(org + pathSeparator + project)
+	if err != nil {
+		return nil, err
+	}
+	return &Project{
+		Orgidentifier: org,
+		Identifier:    space.Identifier,
+		Name:          space.Identifier,
+		Description:   space.Description,
+	}, nil
 }
 
 // FindPipeline returns a pipeline by organization,
@@ -61,13 +88,13 @@ func (c *gitnessClient) FindPipeline(org, project, pipeline string) (*Pipeline,
 // FindSecret returns a secret by organization, project
 // and identifer.
 func (c *gitnessClient) FindSecret(org, project, id string) (*Secret, error) {
-	return nil, fmt.Errorf("not implemented")
+	return c.findSecret(org+pathSeparator+project, id)
 }
 
 // FindSecretOrg returns a secret by organization and
 // identifer.
 func (c *gitnessClient) FindSecretOrg(org, id string) (*Secret, error) {
-	return nil, fmt.Errorf("not implemented")
+	return c.findSecret(org, id)
 }
 
 // FindConnector returns a connector by organization,
@@ -84,21 +111,34 @@ func (c *gitnessClient) FindConnectorOrg(org, conn string) (*Connector, error) {
 
 // CreateOrg creates an organization.
 func (c *gitnessClient) CreateOrg(org *Org) error {
-	return fmt.Errorf("not implemented")
+	return c.createSpace(&gitnessSpace{
+		Identifier:  org.ID,
+		Description: org.Desc,
+	})
 }
 
 // CreateProject creates a project.
 func (c *gitnessClient) CreateProject(project *Project) error {
-	return fmt.Errorf("not implemented")
+	return c.createSpace(&gitnessSpace{
+		Identifier:  project.Identifier,
+		ParentRef:   project.Orgidentifier,
+		Description: project.Description,
+	})
 }
 
 // CreateSecret creates a secret.
 func (c *gitnessClient) CreateSecret(secret *Secret) error {
-	return fmt.Errorf("not implemented")
+	return c.createSecret(secret.Orgidentifier+pathSeparator+secret.Projectidentifier, secret)
 }
 
 // CreateSecret creates an organization secret.
 func (c *gitnessClient) CreateSecretOrg(secret *Secret) error {
+	return c.createSecret(secret.Orgidentifier, secret)
+}
+
+// CreateVariable creates an organization or project
+// variable.
+func (c *gitnessClient) CreateVariable(variable *Variable) error {
 	return fmt.Errorf("not implemented")
 }
 
@@ -116,7 +156,46 @@ func (c *gitnessClient) CreateConnectorOrg(connector *Connector) error {
 // organization and pipeline identifier, with the
 // given identifier and name.
 func (c *gitnessClient) CreatePipeline(org, project string, pipeline []byte) error {
-	return fmt.Errorf("not implemented")
+	return fmt.Errorf("not implemented: gitness pipelines are stored in a repository")
+}
+
+// CreateRemotePipeline creates a pipeline for the
+// organization and project, committing the yaml to
+// the git repository of the store. The repository name
+// of the store is the gitness repository reference.
+func (c *gitnessClient) CreateRemotePipeline(org, project string, pipeline []byte, store *GitStore) error {
+	repoRef := strings.ReplaceAll(store.RepoName, pathSeparator, encodedPathSeparator)
+	commit := &gitnessCommitInput{
+		Title:  store.CommitMsg,
+		Branch: store.Branch,
+		Actions: []*gitnessCommitAction{
+			{
+				Action:  "CREATE",
+				Path:    store.FilePath,
+				Payload: string(pipeline),
+			},
+		},
+	}
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/commits",
+		c.address,
+		repoRef,
+	)
+	// the file already exists if the pipeline was
+	// imported before.
+	if err := c.post(uri, commit, nil); err != nil && !errors.Is(err, ErrDuplicate) {
+		return err
+	}
+
+	in := &gitnessPipeline{
+		Identifier:    strings.TrimSuffix(path.Base(store.FilePath), path.Ext(store.FilePath)),
+		DefaultBranch: store.Branch,
+		ConfigPath:    store.FilePath,
+	}
+	uri = fmt.Sprintf("%s/api/v1/repos/%s/pipelines",
+		c.address,
+		repoRef,
+	)
+	return c.post(uri, in, nil)
 }
 
 func (c *gitnessClient) CreateRepository(parentRef string, repo *CreateRepositoryInput) (*Repository, error) {
@@ -191,6 +270,29 @@ func (c *gitnessClient) CreateRepositoryForMigration(in *CreateRepositoryForMigr
 	return out, nil
 }
 
+func (c *gitnessClient) UpdateRepository(repoRef string, in *UpdateRepositoryInput) (*Repository, error) {
+	out := new(Repository)
+	repoRef = strings.ReplaceAll(repoRef, pathSeparator, encodedPathSeparator)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s",
+		c.address,
+		repoRef,
+	)
+
+	if err := c.patch(uri, in, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitnessClient) ArchiveRepository(repoRef string) error {
+	repoRef = strings.ReplaceAll(repoRef, pathSeparator, encodedPathSeparator)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/archive",
+		c.address,
+		repoRef,
+	)
+	return c.post(uri, nil, nil)
+}
+
 func (c *gitnessClient) UpdateRepositoryState(repoRef string, in *UpdateRepositoryStateInput) (*Repository, error) {
 	out := new(Repository)
 	repoRef = strings.ReplaceAll(repoRef, pathSeparator, encodedPathSeparator)
@@ -259,6 +361,15 @@ func (c *gitnessClient) ImportLabels(repoRef string, in *types.LabelsInput) erro
 	return nil
 }
 
+func (c *gitnessClient) ImportSpaceLabels(spaceRef string, in *types.LabelsInput) error {
+	spaceRef = strings.ReplaceAll(strings.Trim(spaceRef, "/"), pathSeparator, encodedPathSeparator)
+	uri := fmt.Sprintf("%s/api/v1/migrate/spaces/%s/labels",
+		c.address,
+		spaceRef,
+	)
+	return c.post(uri, in, nil)
+}
+
 func (c *gitnessClient) CheckUsers(in *types.CheckUsersInput) (*types.CheckUsersOutput, error) {
 	out := new(types.CheckUsersOutput)
 	uri := fmt.Sprintf("%s/api/v1/principals/check-emails", c.address)
@@ -269,6 +380,50 @@ func (c *gitnessClient) CheckUsers(in *types.CheckUsersInput) (*types.CheckUsers
 	return out, nil
 }
 
+// InviteUser creates the user with a random password, as Gitness
+// does not support invitations. The password can be reset by an admin.
+func (c *gitnessClient) InviteUser(email string) (string, error) {
+	password := make([]byte, 24)
+	if _, err := rand.Read(password); err != nil {
+		return "", err
+	}
+
+	local, _, _ := strings.Cut(email, "@")
+	uid, err := c.availableUID(invalidUIDChars.ReplaceAllString(local, "-"))
+	if err != nil {
+		return "", err
+	}
+	in := &userCreateInput{
+		UID:         uid,
+		Email:       email,
+		DisplayName: local,
+		Password:    base64.RawURLEncoding.EncodeToString(password),
+	}
+	uri := fmt.Sprintf("%s/api/v1/admin/users", c.address)
+	if err := c.post(uri, in, nil); err != nil {
+		return "", err
+	}
+	return "USER_CREATED", nil
+}
+
+// helper function returns the uid with the lowest numeric
+// suffix that is not taken, as users with the same email
+// name on different domains share the uid.
+func (c *gitnessClient) availableUID(uid string) (string, error) {
+	candidate := uid
+	for i := 1; ; i++ {
+		uri := fmt.Sprintf("%s/api/v1/admin/users/%s", c.address, candidate)
+		err := c.get(uri, nil)
+		if errors.Is(err, ErrNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		candidate = fmt.Sprintf("%s-%d", uid, i)
+	}
+}
+
 // GetRepository returns metadata about a repository.
 func (c *gitnessClient) GetRepository(repoRef string) (*Repository, error) {
 	repoRef = strings.ReplaceAll(repoRef, pathSeparator, encodedPathSeparator)
@@ -285,6 +440,140 @@ func (c *gitnessClient) GetRepository(repoRef string) (*Repository, error) {
 	return &out, nil
 }
 
+// ListBranches returns a page of branches of a repository.
+func (c *gitnessClient) ListBranches(repoRef string, page, limit int) ([]*Reference, error) {
+	var out []*Reference
+	err := c.list(repoRef, "branches", fmt.Sprintf("page=%d&limit=%d", page, limit), &out)
+	return out, err
+}
+
+// ListTags returns a page of tags of a repository.
+func (c *gitnessClient) ListTags(repoRef string, page, limit int) ([]*Reference, error) {
+	var out []*Reference
+	err := c.list(repoRef, "tags", fmt.Sprintf("page=%d&limit=%d", page, limit), &out)
+	return out, err
+}
+
+// ListPullRequests returns a page of pull requests of a repository in any state.
+func (c *gitnessClient) ListPullRequests(repoRef string, page, limit int) ([]*PullReq, error) {
+	var out []*PullReq
+	err := c.list(repoRef, "pullreq", fmt.Sprintf("state=open&state=closed&state=merged&page=%d&limit=%d", page, limit), &out)
+	return out, err
+}
+
+// ListPullRequestActivities returns the activities of a pull request.
+func (c *gitnessClient) ListPullRequestActivities(repoRef string, number int) ([]*PullReqActivity, error) {
+	var out []*PullReqActivity
+	err := c.list(repoRef, fmt.Sprintf("pullreq/%d/activities", number), "", &out)
+	return out, err
+}
+
+// ListLabels returns a page of labels of a repository, including inherited labels.
+func (c *gitnessClient) ListLabels(repoRef string, page, limit int) ([]*Label, error) {
+	var out []*Label
+	err := c.list(repoRef, "labels", fmt.Sprintf("inherited=true&page=%d&limit=%d", page, limit), &out)
+	return out, err
+}
+
+// ListWebhooks returns a page of webhooks of a repository.
+func (c *gitnessClient) ListWebhooks(repoRef string, page, limit int) ([]*Webhook, error) {
+	var out []*Webhook
+	err := c.list(repoRef, "webhooks", fmt.Sprintf("page=%d&limit=%d", page, limit), &out)
+	return out, err
+}
+
+// ListRules returns a page of protection rules of a repository.
+func (c *gitnessClient) ListRules(repoRef string, page, limit int) ([]*Rule, error) {
+	var out []*Rule
+	err := c.list(repoRef, "rules", fmt.Sprintf("page=%d&limit=%d", page, limit), &out)
+	return out, err
+}
+
+// UploadAttachment uploads a file to a repository and returns its url.
+func (c *gitnessClient) UploadAttachment(repoRef string, data []byte) (string, error) {
+	repoRef = strings.ReplaceAll(repoRef, pathSeparator, encodedPathSeparator)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/uploads",
+		c.address,
+		repoRef,
+	)
+
+	out := new(Upload)
+	if err := c.post(uri, bytes.NewBuffer(data), out); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s", uri, out.FilePath), nil
+}
+
+// helper function lists a sub resource of a repository.
+func (c *gitnessClient) list(repoRef, resource, params string, out interface{}) error {
+	repoRef = strings.ReplaceAll(repoRef, pathSeparator, encodedPathSeparator)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/%s",
+		c.address,
+		repoRef,
+		resource,
+	)
+	if params != "" {
+		uri = uri + "?" + params
+	}
+	return c.get(uri, out)
+}
+
+// helper function returns the gitness space.
+func (c *gitnessClient) findSpace(spaceRef string) (*gitnessSpace, error) {
+	out := new(gitnessSpace)
+	spaceRef = strings.ReplaceAll(spaceRef, pathSeparator, encodedPathSeparator)
+	uri := fmt.Sprintf("%s/api/v1/spaces/%s",
+		c.address,
+		spaceRef,
+	)
+	if err := c.get(uri, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// helper function creates a gitness space.
+func (c *gitnessClient) createSpace(in *gitnessSpace) error {
+	uri := fmt.Sprintf("%s/api/v1/spaces",
+		c.address,
+	)
+	return c.post(uri, in, nil)
+}
+
+// helper function returns the gitness secret of the space.
+func (c *gitnessClient) findSecret(spaceRef, id string) (*Secret, error) {
+	out := new(gitnessSecret)
+	secretRef := strings.ReplaceAll(spaceRef+pathSeparator+id, pathSeparator, encodedPathSeparator)
+	uri := fmt.Sprintf("%s/api/v1/secrets/%s",
+		c.address,
+		secretRef,
+	)
+	if err := c.get(uri, out); err != nil {
+		return nil, err
+	}
+	return &Secret{
+		Name:        out.Identifier,
+		Identifier:  out.Identifier,
+		Description: out.Description,
+	}, nil
+}
+
+// helper function creates a gitness secret in the space.
+func (c *gitnessClient) createSecret(spaceRef string, secret *Secret) error {
+	in := &gitnessSecret{
+		SpaceRef:    spaceRef,
+		Identifier:  secret.Identifier,
+		Description: secret.Description,
+	}
+	if secret.Spec != nil && secret.Spec.Value != nil {
+		in.Data = *secret.Spec.Value
+	}
+	uri := fmt.Sprintf("%s/api/v1/secrets",
+		c.address,
+	)
+	return c.post(uri, in, nil)
+}
+
 // http request helper functions
 func (c *gitnessClient) setAuthHeader() func(h *http.Header) {
 	return func(h *http.Header) { h.Set("Authorization", c.token) }
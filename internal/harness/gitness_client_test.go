@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package harness
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gotidy/ptr"
+	"github.com/h2non/gock"
+)
+
+func TestGitnessFindOrg(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitness.example.com").
+		Get("/api/v1/spaces/acme").
+		MatchHeader("Authorization", "dummy0d0ac576df34be6a882").
+		Reply(200).
+		JSON(map[string]interface{}{"identifier": "acme", "path": "acme", "description": "acme corp"})
+
+	client := NewGitness("dummy0d0ac576df34be6a882", "https://gitness.example.com")
+	got, err := client.FindOrg("acme")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got.ID != "acme" || got.Desc != "acme corp" {
+		t.Errorf("Unexpected organization %+v", got)
+	}
+}
+
+func TestGitnessCreateSecret(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitness.example.com").
+		Post("/api/v1/secrets").
+		JSON(map[string]string{"space_ref": "acme/playground", "identifier": "token", "data": "s3cr3t"}).
+		Reply(201)
+
+	client := NewGitness("dummy0d0ac576df34be6a882", "https://gitness.example.com")
+	err := client.CreateSecret(&Secret{
+		Identifier:        "token",
+		Orgidentifier:     "acme",
+		Projectidentifier: "playground",
+		Spec:              &SecretText{Value: ptr.String("s3cr3t")},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect secret request")
+	}
+}
+
+func TestGitnessCreateRemotePipeline(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitness.example.com").
+		Post("/api/v1/repos/.+/commits").
+		JSON(map[string]interface{}{
+			"title":  "Add pipeline build",
+			"branch": "main",
+			"actions": []map[string]string{
+				{"action": "CREATE", "path": ".harness/build.yaml", "payload": "spec: {}"},
+			},
+		}).
+		Reply(200)
+
+	gock.New("https://gitness.example.com").
+		Post("/api/v1/repos/.+/pipelines").
+		JSON(map[string]string{"identifier": "build", "default_branch": "main", "config_path": ".harness/build.yaml"}).
+		Reply(201)
+
+	client := NewGitness("dummy0d0ac576df34be6a882", "https://gitness.example.com")
+	err := client.CreateRemotePipeline("acme", "playground", []byte("spec: {}"), &GitStore{
+		RepoName:  "acme/playground/hello-world",
+		Branch:    "main",
+		FilePath:  ".harness/build.yaml",
+		CommitMsg: "Add pipeline build",
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect commit and pipeline requests")
+	}
+}
+
+func TestGitnessListBranches(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitness.example.com").
+		Get("/api/v1/repos/.+/branches").
+		MatchParam("page", "2").
+		MatchParam("limit", "100").
+		Reply(200).
+		JSON([]map[string]string{{"name": "main", "sha": "a1b2c3"}})
+
+	client := NewGitness("dummy0d0ac576df34be6a882", "https://gitness.example.com")
+	got, err := client.ListBranches("acme/hello-world", 2, 100)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(got) != 1 || got[0].Name != "main" || got[0].SHA != "a1b2c3" {
+		t.Errorf("Unexpected branches %+v", got)
+	}
+}
+
+func TestGitnessInviteUser(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitness.example.com").
+		Get("/api/v1/admin/users/john").
+		Reply(200).
+		JSON(map[string]string{"uid": "john", "email": "john@a.com"})
+
+	gock.New("https://gitness.example.com").
+		Get("/api/v1/admin/users/john-1").
+		Reply(404).
+		JSON(map[string]string{"message": "user not found"})
+
+	gock.New("https://gitness.example.com").
+		Post("/api/v1/admin/users").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			in := new(userCreateInput)
+			err := json.NewDecoder(req.Body).Decode(in)
+			return err == nil && in.UID == "john-1" && in.Email == "john@b.com", err
+		}).
+		Reply(201)
+
+	client := NewGitness("dummy0d0ac576df34be6a882", "https://gitness.example.com")
+	if _, err := client.InviteUser("john@b.com"); err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expect user created with a suffixed uid")
+	}
+}
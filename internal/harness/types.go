@@ -27,6 +27,16 @@ type (
 		Lastupdatedat int64  `json:"lastUpdatedAt"`
 	}
 
+	// GitStore defines the git repository where a remote
+	// pipeline yaml is stored.
+	GitStore struct {
+		ConnectorRef string
+		RepoName     string
+		Branch       string
+		FilePath     string
+		CommitMsg    string
+	}
+
 	// Project defines a project.
 	Project struct {
 		Orgidentifier string   `json:"orgIdentifier"`
@@ -61,6 +71,46 @@ type (
 		PullRequestNumber int    `json:"num_pulls"`
 	}
 
+	// Reference defines a git branch or tag.
+	Reference struct {
+		Name string `json:"name"`
+		SHA  string `json:"sha"`
+	}
+
+	// PullReq defines a pull request.
+	PullReq struct {
+		Number int    `json:"number"`
+		State  string `json:"state"` // open, closed, merged
+	}
+
+	// PullReqActivity defines a pull request activity,
+	// like a comment or a review.
+	PullReqActivity struct {
+		Kind    string `json:"kind"` // comment, change-comment, system
+		Text    string `json:"text"`
+		Deleted *int64 `json:"deleted,omitempty"`
+	}
+
+	// Label defines a repository or space label.
+	Label struct {
+		Key string `json:"key"`
+	}
+
+	// Webhook defines a repository webhook.
+	Webhook struct {
+		Identifier string `json:"identifier"`
+	}
+
+	// Rule defines a repository protection rule.
+	Rule struct {
+		Identifier string `json:"identifier"`
+	}
+
+	// Upload defines a file uploaded to a repository.
+	Upload struct {
+		FilePath string `json:"file_path"`
+	}
+
 	// RepoSettings defines general repository settings which are externally accessible
 	RepoSettings struct {
 		FileSizeLimit *int64 `json:"file_size_limit"`
@@ -101,13 +151,44 @@ type (
 	}
 )
 
+//
+// Variable types
+//
+
+const (
+	// DefaultVariableType defines the default variable type.
+	DefaultVariableType = "String"
+
+	// DefaultVariableValueType defines the default variable value type.
+	DefaultVariableValueType = "FIXED"
+)
+
+type (
+	Variable struct {
+		Name              string        `json:"name"`
+		Identifier        string        `json:"identifier"`
+		Orgidentifier     string        `json:"orgIdentifier,omitempty"`
+		Projectidentifier string        `json:"projectIdentifier,omitempty"`
+		Description       string        `json:"description,omitempty"`
+		Type              string        `json:"type"` // String
+		Spec              *VariableSpec `json:"spec"`
+	}
+
+	VariableSpec struct {
+		Type  string `json:"valueType"` // FIXED
+		Value string `json:"fixedValue"`
+	}
+)
+
 //
 // Connector types
 //
 
 const (
-	ConnectorTypeGithub = "Github"
-	ConnectorTypeGitlab = "Gitlab"
+	ConnectorTypeGithub    = "Github"
+	ConnectorTypeGitlab    = "Gitlab"
+	ConnectorTypeBitbucket = "Bitbucket"
+	ConnectorTypeAzureRepo = "AzureRepo"
 )
 
 type (
@@ -153,11 +234,38 @@ type (
 		Apiaccess         *Resource `json:"apiAccess"`
 	}
 
+	// ConnectorBitbucket defines a Bitbucket connector.
+	ConnectorBitbucket struct {
+		Executeondelegate bool      `json:"executeOnDelegate"`
+		Type              string    `json:"type"` // Account
+		URL               string    `json:"url"`
+		Validationrepo    string    `json:"validationRepo,omitempty"`
+		Authentication    *Resource `json:"authentication"`
+		Apiaccess         *Resource `json:"apiAccess"`
+	}
+
+	// ConnectorAzureRepo defines an Azure Repos connector.
+	ConnectorAzureRepo struct {
+		Executeondelegate bool      `json:"executeOnDelegate"`
+		Type              string    `json:"type"` // Project
+		URL               string    `json:"url"`
+		Validationrepo    string    `json:"validationRepo,omitempty"`
+		Authentication    *Resource `json:"authentication"`
+		Apiaccess         *Resource `json:"apiAccess"`
+	}
+
 	// ConnectorToken defines connector credentials.
 	ConnectorToken struct {
 		Username string `json:"username,omitempty"`
 		Tokenref string `json:"tokenRef,omitempty"`
 	}
+
+	// ConnectorPassword defines connector username and
+	// password credentials.
+	ConnectorPassword struct {
+		Username    string `json:"username,omitempty"`
+		Passwordref string `json:"passwordRef,omitempty"`
+	}
 )
 
 //
@@ -229,6 +337,30 @@ type (
 		} `json:"data"`
 	}
 
+	// Request envelope to invite users to the account
+	userInviteEnvelope struct {
+		Emails       []string      `json:"emails"`
+		UserGroups   []string      `json:"userGroups"`
+		RoleBindings []interface{} `json:"roleBindings"`
+	}
+
+	// Response envelope of the user invite, with the
+	// result by email
+	userInviteResponseEnvelope struct {
+		Status string `json:"status"`
+		Data   *struct {
+			AddUserResponseMap map[string]string `json:"addUserResponseMap"`
+		} `json:"data"`
+	}
+
+	// Request to create a user on Gitness
+	userCreateInput struct {
+		UID         string `json:"uid"`
+		Email       string `json:"email"`
+		DisplayName string `json:"display_name"`
+		Password    string `json:"password"`
+	}
+
 	// Request envelope for the Connector type
 	connectorCreateEnvelope struct {
 		Connector *Connector `json:"connector"`
@@ -250,6 +382,21 @@ type (
 		Secret *Secret `json:"secret"`
 	}
 
+	// Response envelope for the Variable type
+	variableEnvelope struct {
+		Status string `json:"status"`
+		Data   *struct {
+			Variable       *Variable `json:"variable"`
+			Createdat      int64     `json:"createdAt"`
+			Lastmodifiedat int64     `json:"lastModifiedAt"`
+		} `json:"data"`
+	}
+
+	// Request envelope for the Variable type
+	variableCreateEnvelope struct {
+		Variable *Variable `json:"variable"`
+	}
+
 	// CreateRepositoryInput defines a repo creation request input.
 	CreateRepositoryInput struct {
 		Identifier    string `json:"identifier"`
@@ -271,6 +418,12 @@ type (
 		ParentRef     string `json:"parent_ref"`
 	}
 
+	// UpdateRepositoryInput defines a repo update request input.
+	UpdateRepositoryInput struct {
+		Description *string           `json:"description,omitempty"`
+		Tags        map[string]string `json:"tags,omitempty"`
+	}
+
 	// UpdateRepositoryStateInput defines a repo update state request input.
 	UpdateRepositoryStateInput struct {
 		State enum.RepoState `json:"state"`
@@ -281,3 +434,50 @@ type (
 		Name string `json:"name"`
 	}
 )
+
+//
+// Gitness types
+//
+
+type (
+	// gitnessSpace defines a gitness space, which is the
+	// equivalent of an organization or project.
+	gitnessSpace struct {
+		Identifier  string `json:"identifier"`
+		ParentRef   string `json:"parent_ref,omitempty"`
+		Path        string `json:"path,omitempty"`
+		Description string `json:"description,omitempty"`
+		IsPublic    bool   `json:"is_public"`
+	}
+
+	// gitnessSecret defines a gitness secret.
+	gitnessSecret struct {
+		SpaceRef    string `json:"space_ref,omitempty"`
+		Identifier  string `json:"identifier"`
+		Description string `json:"description,omitempty"`
+		Data        string `json:"data,omitempty"`
+	}
+
+	// gitnessPipeline defines a gitness pipeline, stored
+	// in the git repository.
+	gitnessPipeline struct {
+		Identifier    string `json:"identifier"`
+		DefaultBranch string `json:"default_branch"`
+		ConfigPath    string `json:"config_path"`
+	}
+
+	// gitnessCommitInput defines a gitness commit request
+	// input.
+	gitnessCommitInput struct {
+		Title   string                 `json:"title"`
+		Branch  string                 `json:"branch"`
+		Actions []*gitnessCommitAction `json:"actions"`
+	}
+
+	// gitnessCommitAction defines a file change of a commit.
+	gitnessCommitAction struct {
+		Action  string `json:"action"` // CREATE
+		Path    string `json:"path"`
+		Payload string `json:"payload"`
+	}
+)
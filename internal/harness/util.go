@@ -34,6 +34,32 @@ const (
 	spacePath         = "space_path"
 )
 
+// getQueryParamsFromSpaceRef returns the encoded query params from the spaceRef and escaped version of spaceRef
+func getQueryParamsFromSpaceRef(spaceRef string) (string, string, error) {
+	params := url.Values{}
+	spaceRefParts := strings.Split(strings.Trim(spaceRef, "/"), "/")
+
+	// valid spaceRef: "Acc", "Acc/Org", "Acc/Org/Projct"
+	if spaceRefParts[0] == "" || len(spaceRefParts) > 3 {
+		return "", "", fmt.Errorf("%w. reference %s has %d segments, want 1-3",
+			ErrInvalidRef, spaceRef, len(spaceRefParts))
+	}
+
+	params.Set(accountIdentifier, spaceRefParts[0])
+	params.Set(routingId, spaceRefParts[0])
+	params.Set(spacePath, strings.Join(spaceRefParts, encodedPathSeparator))
+
+	switch len(spaceRefParts) {
+	case 2:
+		params.Set(orgIdentifier, spaceRefParts[1])
+	case 3:
+		params.Set(orgIdentifier, spaceRefParts[1])
+		params.Set(projectIdentifier, spaceRefParts[2])
+	}
+
+	return params.Encode(), strings.Join(spaceRefParts, encodedPathSeparator), nil
+}
+
 // getQueryParamsFromRepoRef returns the encoded query params from the repoRef and escaped version of repoRef
 func getQueryParamsFromRepoRef(repoRef string) (string, string, error) {
 	params := url.Values{}
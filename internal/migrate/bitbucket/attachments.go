@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitbucket
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/harness/harness-migrate/internal/gitexporter"
+)
+
+// DownloadAttachment downloads images and files uploaded to pull requests
+// and comments, which are hosted on bitbucket.org.
+func (e *Export) DownloadAttachment(ctx context.Context, repoSlug string, link string, limit int64) ([]byte, error) {
+	u, err := url.Parse(link)
+	if err != nil || u.Host != "bitbucket.org" {
+		return nil, nil
+	}
+	if !strings.Contains(u.Path, "/images/") &&
+		!strings.Contains(u.Path, "/attachments/") &&
+		!strings.HasPrefix(u.Path, "/"+repoSlug+"/downloads/") {
+		return nil, nil
+	}
+	return gitexporter.DownloadAttachment(ctx, e.bitbucket, link, limit)
+}
@@ -60,6 +60,32 @@ func (e *Export) ListPRComments(
 	return e.convertPRCommentsList(out.Values, prNumber, repoSlug), res, err
 }
 
+func (e *Export) GetPRParticipants(
+	ctx context.Context,
+	repoSlug string,
+	prNumber int,
+) (*pullRequestParticipants, *scm.Response, error) {
+	path := fmt.Sprintf("/2.0/repositories/%s/pullrequests/%d?fields=%s", repoSlug, prNumber, participantFields)
+	out := new(pullRequestParticipants)
+	res, err := e.do(ctx, "GET", path, nil, out)
+	return out, res, err
+}
+
+// ListPRActivities returns a page of the pull request activity log. The
+// log is paginated with an opaque cursor, the path of the next page is
+// returned with the response.
+func (e *Export) ListPRActivities(
+	ctx context.Context,
+	path string,
+) (*activities, *scm.Response, error) {
+	out := new(activities)
+	res, err := e.do(ctx, "GET", path, nil, out)
+	if res != nil {
+		res.Page.NextURL = out.Next
+	}
+	return out, res, err
+}
+
 func (e *Export) ListBranchRulesInternal(
 	ctx context.Context,
 	repoSlug string,
@@ -73,6 +99,12 @@ func (e *Export) ListBranchRulesInternal(
 }
 
 func (e *Export) do(ctx context.Context, method, path string, in, out interface{}) (*scm.Response, error) {
+	return do(ctx, e.bitbucket, e.tracer, method, path, in, out)
+}
+
+// do executes a bitbucket api request using the scm client
+// transport.
+func do(ctx context.Context, client *scm.Client, tracer tracer.Tracer, method, path string, in, out interface{}) (*scm.Response, error) {
 	req := &scm.Request{
 		Method: method,
 		Path:   path,
@@ -89,7 +121,7 @@ func (e *Export) do(ctx context.Context, method, path string, in, out interface{
 	}
 
 	// execute the http request
-	res, err := e.bitbucket.Do(ctx, req)
+	res, err := client.Do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +136,7 @@ func (e *Export) do(ctx context.Context, method, path string, in, out interface{
 	)
 
 	if nearLimit {
-		e.tracer.Debug().Log("Near Bitbucket rate limit. Less than 20%% of the requests remained.")
+		tracer.Debug().Log("Near Bitbucket rate limit. Less than 20%% of the requests remained.")
 	}
 
 	// if an error is encountered, unmarshal and return the
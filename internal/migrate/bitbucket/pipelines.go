@@ -0,0 +1,204 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/harness-migrate/internal/common"
+	"github.com/harness/harness-migrate/internal/tracer"
+	"github.com/harness/harness-migrate/internal/types"
+
+	"github.com/drone/go-convert/convert/bitbucket"
+	"github.com/drone/go-scm/scm"
+)
+
+// pipelineFile is the bitbucket pipelines configuration file.
+const pipelineFile = "bitbucket-pipelines.yml"
+
+type (
+	// PipelineExporter exports bitbucket pipelines and
+	// variables of a workspace.
+	PipelineExporter struct {
+		Bitbucket      *scm.Client
+		Workspace      string
+		RepositoryList []string
+
+		DockerConn string
+		KubeName   string
+		KubeConn   string
+
+		Tracer tracer.Tracer
+	}
+
+	pipelineVariable struct {
+		Key     string `json:"key"`
+		Value   string `json:"value"`
+		Secured bool   `json:"secured"`
+	}
+
+	pipelineVariables struct {
+		pagination
+		Values []*pipelineVariable `json:"values"`
+	}
+)
+
+// Export exports the bitbucket pipeline of every repository
+// in the workspace. Repositories without a pipeline are
+// skipped. Secured variables cannot be read from bitbucket
+// and are exported as placeholder secrets.
+func (m *PipelineExporter) Export(ctx context.Context) (*types.Org, error) {
+	m.Tracer.Start("export workspace %s", m.Workspace)
+
+	dstOrg := &types.Org{
+		Name: m.Workspace,
+	}
+
+	var err error
+	dstOrg.Secrets, dstOrg.Variables, err = m.listVariables(ctx, fmt.Sprintf("/2.0/workspaces/%s/pipelines-config/variables", m.Workspace))
+	if err != nil {
+		m.Tracer.Stop("Failed to export workspace variables: %s", err.Error())
+		return nil, err
+	}
+
+	m.Tracer.Stop("export workspace %s [done]", m.Workspace)
+
+	repos, err := m.listRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, repo := range repos {
+		// Skip repositories that are not in the m.RepositoryList
+		if len(m.RepositoryList) > 0 && !m.repositoryInList(repo.Name) {
+			continue
+		}
+
+		m.Tracer.Start("export project %s", repo.Name)
+
+		repoSlug := scm.Join(repo.Namespace, repo.Name)
+		content, res, err := m.Bitbucket.Contents.Find(ctx, repoSlug, pipelineFile, repo.Branch)
+		if err != nil {
+			if res != nil && res.Status == 404 {
+				m.Tracer.Stop("Skipping repository %s: no %s file found.", repo.Name, pipelineFile)
+				continue
+			}
+			return nil, fmt.Errorf("failed to find %s for repo %s: %w", pipelineFile, repoSlug, err)
+		}
+
+		converter := bitbucket.New(
+			bitbucket.WithDockerhub(m.DockerConn),
+			bitbucket.WithKubernetes(m.KubeName, m.KubeConn),
+		)
+		converted, err := converter.ConvertBytes(content.Data)
+		if err != nil {
+			m.Tracer.Stop("Skipping repository %s: failed to convert %s: %s", repo.Name, pipelineFile, err.Error())
+			continue
+		}
+
+		dstProject := &types.Project{
+			Name:   repo.Name,
+			Type:   "bitbucket",
+			Repo:   repo.Clone,
+			Branch: repo.Branch,
+			Pipelines: []*types.Pipeline{
+				{
+					Name:   repo.Name,
+					Repo:   repo.Clone,
+					Branch: repo.Branch,
+					Type:   "bitbucket",
+					Yaml:   string(converted),
+				},
+			},
+		}
+
+		dstProject.Secrets, dstProject.Variables, err = m.listVariables(ctx, fmt.Sprintf("/2.0/repositories/%s/pipelines_config/variables", repoSlug))
+		if err != nil {
+			return nil, err
+		}
+
+		dstOrg.Projects = append(dstOrg.Projects, dstProject)
+
+		m.Tracer.Stop("export project %s [done]", repo.Name)
+	}
+
+	return dstOrg, nil
+}
+
+// listRepositories returns all repositories in the workspace.
+func (m *PipelineExporter) listRepositories(ctx context.Context) ([]*scm.Repository, error) {
+	var repos []*scm.Repository
+	opts := scm.ListOptions{Size: common.DefaultLimit}
+	for {
+		result, res, err := m.Bitbucket.Repositories.ListNamespace(ctx, m.Workspace, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repos for workspace %s: %w", m.Workspace, err)
+		}
+		repos = append(repos, result...)
+		if res.Page.Next == 0 {
+			break
+		}
+		opts.Page = res.Page.Next
+	}
+	return repos, nil
+}
+
+// listVariables returns the pipeline variables at the given
+// api path. Secured variables are returned as placeholder
+// secrets, all others as variables.
+func (m *PipelineExporter) listVariables(ctx context.Context, path string) ([]*types.Secret, []*types.Variable, error) {
+	var secrets []*types.Secret
+	var variables []*types.Variable
+	opts := types.ListOptions{Page: 1}
+	for {
+		out := new(pipelineVariables)
+		res, err := do(ctx, m.Bitbucket, m.Tracer, "GET", path+"?"+encodeListOptions(opts), nil, out)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list variables %s: %w", path, err)
+		}
+		for _, src := range out.Values {
+			if src.Secured {
+				secrets = append(secrets, &types.Secret{
+					Name:        src.Key,
+					Desc:        src.Key,
+					Placeholder: true,
+				})
+				continue
+			}
+			variables = append(variables, &types.Variable{
+				Name:  src.Key,
+				Value: src.Value,
+			})
+		}
+		copyPagination(out.pagination, res)
+		if res.Page.Next == 0 {
+			break
+		}
+		opts.Page = res.Page.Next
+	}
+	return secrets, variables, nil
+}
+
+func (m *PipelineExporter) repositoryInList(repoName string) bool {
+	lowerRepoName := strings.ToLower(repoName)
+	for _, name := range m.RepositoryList {
+		if strings.ToLower(name) == lowerRepoName {
+			return true
+		}
+	}
+	return false
+}
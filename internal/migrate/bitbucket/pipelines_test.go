@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitbucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/harness-migrate/internal/tracer"
+
+	scmbitbucket "github.com/drone/go-scm/scm/driver/bitbucket"
+	"github.com/h2non/gock"
+)
+
+const testPipeline = `image: golang:1.21
+pipelines:
+  default:
+    - step:
+        script:
+          - go test ./...
+`
+
+func TestPipelineExport(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/workspaces/acme/pipelines-config/variables").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"values": []map[string]interface{}{
+				{"key": "DEPLOY_TOKEN", "secured": true},
+				{"key": "REGION", "value": "eu-west-1", "secured": false},
+			},
+		})
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/acme").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"values": []map[string]interface{}{
+				{"full_name": "acme/api", "name": "api", "mainbranch": map[string]string{"name": "main"}},
+				{"full_name": "acme/docs", "name": "docs", "mainbranch": map[string]string{"name": "main"}},
+			},
+		})
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/acme/api/src/main/bitbucket-pipelines.yml").
+		Reply(200).
+		BodyString(testPipeline)
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/acme/api/pipelines_config/variables").
+		Reply(200).
+		JSON(map[string]interface{}{
+			"values": []map[string]interface{}{
+				{"key": "NPM_TOKEN", "secured": true},
+			},
+		})
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/acme/docs/src/main/bitbucket-pipelines.yml").
+		Reply(404).
+		JSON(map[string]interface{}{"type": "error"})
+
+	exporter := &PipelineExporter{
+		Bitbucket: scmbitbucket.NewDefault(),
+		Workspace: "acme",
+		Tracer:    tracer.Default(),
+	}
+	org, err := exporter.Export(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(org.Secrets), 1; got != want {
+		t.Fatalf("want %d workspace secrets, got %d", want, got)
+	}
+	if got, want := org.Secrets[0].Name, "DEPLOY_TOKEN"; got != want {
+		t.Errorf("want secret name %q, got %q", want, got)
+	}
+	if !org.Secrets[0].Placeholder {
+		t.Errorf("want secured variable exported as placeholder")
+	}
+	if got, want := len(org.Variables), 1; got != want {
+		t.Fatalf("want %d workspace variables, got %d", want, got)
+	}
+	if got, want := len(org.Projects), 1; got != want {
+		t.Fatalf("want %d projects, got %d", want, got)
+	}
+
+	project := org.Projects[0]
+	if got, want := project.Branch, "main"; got != want {
+		t.Errorf("want project branch %q, got %q", want, got)
+	}
+	if got, want := len(project.Pipelines), 1; got != want {
+		t.Fatalf("want %d pipelines, got %d", want, got)
+	}
+	if project.Pipelines[0].Yaml == "" {
+		t.Errorf("want converted pipeline yaml")
+	}
+	if got, want := len(project.Secrets), 1; got != want {
+		t.Fatalf("want %d project secrets, got %d", want, got)
+	}
+	if !gock.IsDone() {
+		t.Errorf("pending mocks: %v", gock.Pending())
+	}
+}
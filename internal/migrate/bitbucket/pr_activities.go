@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/harness/harness-migrate/internal/common"
+	"github.com/harness/harness-migrate/internal/types"
+)
+
+// ListPullRequestActivities returns the state changes of the pull request
+// derived from the updates in its activity log.
+func (e *Export) ListPullRequestActivities(ctx context.Context, repoSlug string, prNumber int) ([]*types.PRActivity, error) {
+	e.tracer.Debug().Start(common.MsgStartExportPrActivities, repoSlug, prNumber)
+	var activities []*types.PRActivity
+	defer func() {
+		e.tracer.Debug().Stop(common.MsgCompleteExportPrActivities, len(activities), repoSlug, prNumber)
+	}()
+
+	var updates []*activityUpdate
+	path := fmt.Sprintf("/2.0/repositories/%s/pullrequests/%d/activity?%s",
+		repoSlug, prNumber, encodeListOptions(types.ListOptions{Size: 50}))
+	for path != "" {
+		out, res, err := e.ListPRActivities(ctx, path)
+		if err != nil {
+			e.tracer.LogError(common.ErrListActivities, repoSlug, prNumber, err)
+			return nil, fmt.Errorf(common.ErrListActivities, repoSlug, prNumber, err)
+		}
+		for _, value := range out.Values {
+			if value.Update != nil {
+				updates = append(updates, value.Update)
+			}
+		}
+
+		path = ""
+		if next, err := url.Parse(res.Page.NextURL); err == nil && res.Page.NextURL != "" {
+			path = next.RequestURI()
+		}
+	}
+
+	activities = convertPRActivities(updates)
+	for _, activity := range activities {
+		email, err := e.GetDefaultEmail(ctx, activity.Actor.ID, activity.Actor.Name) // ID holds account_id value
+		if err != nil {
+			return nil, fmt.Errorf("cannot find email for actor %s: %w", activity.Actor.Name, err)
+		}
+		activity.Actor.Email = email
+	}
+	return activities, nil
+}
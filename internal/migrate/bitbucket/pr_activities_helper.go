@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitbucket
+
+import (
+	"sort"
+
+	"github.com/harness/harness-migrate/internal/types"
+	externalTypes "github.com/harness/harness-migrate/types"
+)
+
+// convertPRActivities compares consecutive updates of the pull request
+// and returns an activity for every change of the state or draft flag.
+func convertPRActivities(updates []*activityUpdate) []*types.PRActivity {
+	// the activity log is ordered newest first.
+	sort.SliceStable(updates, func(i, j int) bool {
+		return updates[i].Date.Before(updates[j].Date)
+	})
+
+	var to []*types.PRActivity
+	for i := 1; i < len(updates); i++ {
+		prev, curr := updates[i-1], updates[i]
+
+		var activityType externalTypes.ActivityType
+		switch {
+		case curr.State != prev.State && curr.State == "MERGED":
+			activityType = externalTypes.ActivityMerged
+		case curr.State != prev.State && curr.State == "DECLINED":
+			activityType = externalTypes.ActivityClosed
+		case curr.State != prev.State && curr.State == "OPEN":
+			activityType = externalTypes.ActivityReopened
+		case curr.Draft != prev.Draft && curr.Draft:
+			activityType = externalTypes.ActivityDraft
+		case curr.Draft != prev.Draft:
+			activityType = externalTypes.ActivityReadyForReview
+		default:
+			continue
+		}
+
+		to = append(to, &types.PRActivity{
+			Type:    activityType,
+			Actor:   convertUser(curr.Author),
+			Created: curr.Date,
+		})
+	}
+	return to
+}
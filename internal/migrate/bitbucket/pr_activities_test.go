@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"testing"
+
+	externalTypes "github.com/harness/harness-migrate/types"
+)
+
+func TestConvertPRActivities(t *testing.T) {
+	var in activities
+	if err := json.Unmarshal([]byte(`{
+		"values": [
+			{"update": {"state": "MERGED", "author": {"account_id": "2", "display_name": "John"}, "date": "2024-01-04T00:00:00Z"}},
+			{"approval": {"date": "2024-01-03T12:00:00Z"}},
+			{"update": {"state": "OPEN", "author": {"account_id": "1", "display_name": "Jane"}, "date": "2024-01-03T00:00:00Z"}},
+			{"update": {"state": "OPEN", "draft": true, "author": {"account_id": "1", "display_name": "Jane"}, "date": "2024-01-02T00:00:00Z"}},
+			{"update": {"state": "OPEN", "draft": true, "author": {"account_id": "1", "display_name": "Jane"}, "date": "2024-01-01T00:00:00Z"}}
+		]
+	}`), &in); err != nil {
+		t.Fatal(err)
+	}
+
+	var updates []*activityUpdate
+	for _, value := range in.Values {
+		if value.Update != nil {
+			updates = append(updates, value.Update)
+		}
+	}
+
+	got := convertPRActivities(updates)
+	if len(got) != 2 {
+		t.Fatalf("want 2 activities, got %d", len(got))
+	}
+	if got[0].Type != externalTypes.ActivityReadyForReview || got[0].Actor.Name != "Jane" {
+		t.Errorf("want ready for review by Jane, got %s by %s", got[0].Type, got[0].Actor.Name)
+	}
+	if got[1].Type != externalTypes.ActivityMerged || got[1].Actor.ID != "2" {
+		t.Errorf("want merged by John, got %s by %s", got[1].Type, got[1].Actor.Name)
+	}
+}
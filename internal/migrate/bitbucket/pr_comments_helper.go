@@ -26,7 +26,7 @@ import (
 	"github.com/harness/harness-migrate/internal/types"
 )
 
-const commentFields = "values.id,values.type,values.parent.id,values.content.raw,values.user.account_id,values.user.display_name,values.created_on,values.updated_on,values.inline.*"
+const commentFields = "values.id,values.type,values.parent.id,values.content.raw,values.user.account_id,values.user.display_name,values.created_on,values.updated_on,values.inline.*,values.resolution.user.account_id,values.resolution.user.display_name,values.resolution.created_on"
 
 func (e *Export) convertPRCommentsList(from []codeComment, prNumber int, repoSlug string) []*types.PRComment {
 	var to []*types.PRComment
@@ -77,6 +77,21 @@ func (e *Export) convertPRComment(from codeComment, prNumber int, repo string) *
 		},
 		ParentID:    from.Parent.ID,
 		CodeComment: metadata,
+		Resolution:  convertResolution(from.Resolution),
+	}
+}
+
+// convertResolution returns the resolution of a resolved comment thread.
+func convertResolution(from *resolution) *types.Resolution {
+	if from == nil {
+		return nil
+	}
+	return &types.Resolution{
+		Resolved: from.CreatedOn,
+		ResolvedBy: scm.User{
+			ID:   from.User.AccountID,
+			Name: from.User.DisplayName,
+		},
 	}
 }
 
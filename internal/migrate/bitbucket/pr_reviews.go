@@ -13,22 +13,57 @@
 // limitations under the License.
 
 // Package bitbucket provides automatic migration tools from Bitbucket Cloud to Harness.
-
-// ListPullRequestReviews implements gitexporter.Interface.
-
 package bitbucket
 
 import (
 	"context"
+	"fmt"
 
-	"github.com/harness/harness-migrate/internal/codeerror"
+	"github.com/harness/harness-migrate/internal/common"
 	"github.com/harness/harness-migrate/internal/types"
 )
 
+// ListPullRequestReviews returns the approvals and the requested
+// changes of the pull request participants.
 func (e *Export) ListPullRequestReviews(ctx context.Context, repoSlug string, prNumber int, opts types.ListOptions) ([]*types.PRReview, error) {
-	return nil, &codeerror.OpNotSupportedError{Name: "ListPullRequestReviews"}
+	e.tracer.Debug().Start(common.MsgStartExportPrReviewers, repoSlug, prNumber)
+	var reviews []*types.PRReview
+	defer func() {
+		e.tracer.Debug().Stop(common.MsgCompleteExportPrReviewers, len(reviews), repoSlug, prNumber)
+	}()
+
+	participants, _, err := e.GetPRParticipants(ctx, repoSlug, prNumber)
+	if err != nil {
+		e.tracer.LogError(common.ErrListReviewers, repoSlug, prNumber, err)
+		return nil, fmt.Errorf(common.ErrListReviewers, repoSlug, prNumber, err)
+	}
+
+	reviews = convertPRReviews(participants)
+	for _, review := range reviews {
+		email, err := e.GetDefaultEmail(ctx, review.Author.ID, review.Author.Name) // ID holds account_id value
+		if err != nil {
+			return nil, fmt.Errorf("cannot find email for author %s: %w", review.Author.Name, err)
+		}
+		review.Author.Email = email
+	}
+	return reviews, nil
 }
 
+// ListRequestedReviewers returns the reviewers of the pull request.
 func (e *Export) ListRequestedReviewers(ctx context.Context, repoSlug string, prNumber int) ([]*types.PRReviewer, error) {
-	return nil, &codeerror.OpNotSupportedError{Name: "ListRequestedReviewers"}
+	participants, _, err := e.GetPRParticipants(ctx, repoSlug, prNumber)
+	if err != nil {
+		e.tracer.LogError(common.ErrListReviewers, repoSlug, prNumber, err)
+		return nil, fmt.Errorf(common.ErrListReviewers, repoSlug, prNumber, err)
+	}
+
+	reviewers := convertPRReviewers(participants)
+	for _, reviewer := range reviewers {
+		email, err := e.GetDefaultEmail(ctx, reviewer.ID, reviewer.Name) // ID holds account_id value
+		if err != nil {
+			return nil, fmt.Errorf("cannot find email for requested reviewer %s: %w", reviewer.Name, err)
+		}
+		reviewer.Email = email
+	}
+	return reviewers, nil
 }
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitbucket
+
+import (
+	"github.com/harness/harness-migrate/internal/types"
+	"github.com/harness/harness-migrate/internal/types/enum"
+
+	"github.com/drone/go-scm/scm"
+)
+
+const participantFields = "participants.user.account_id,participants.user.display_name,participants.role," +
+	"participants.approved,participants.state,participants.participated_on," +
+	"reviewers.account_id,reviewers.display_name,source.commit.hash,updated_on"
+
+// convertPRReviews converts the approvals and the requested changes
+// of the participants to reviews on the pull request source commit.
+func convertPRReviews(from *pullRequestParticipants) []*types.PRReview {
+	var to []*types.PRReview
+	for _, p := range from.Participants {
+		var decision enum.ReviewDecision
+		switch {
+		case p.State == "changes_requested":
+			decision = enum.ReviewDecisionChangeReq
+		case p.Approved || p.State == "approved":
+			decision = enum.ReviewDecisionApproved
+		default:
+			continue
+		}
+
+		created := from.UpdatedOn
+		if p.ParticipatedOn != nil {
+			created = *p.ParticipatedOn
+		}
+		to = append(to, &types.PRReview{
+			Review: scm.Review{
+				ID:      len(to) + 1, // bitbucket participants have no id
+				Author:  convertUser(p.User),
+				Created: created,
+				Updated: created,
+				Sha:     from.Source.Commit.Hash,
+			},
+			State: decision,
+		})
+	}
+	return to
+}
+
+// convertPRReviewers converts the participants with the reviewer
+// role and the reviewers without participation.
+func convertPRReviewers(from *pullRequestParticipants) []*types.PRReviewer {
+	var to []*types.PRReviewer
+	seen := make(map[string]bool)
+	for _, p := range from.Participants {
+		if p.Role != "REVIEWER" || seen[p.User.AccountID] {
+			continue
+		}
+		seen[p.User.AccountID] = true
+		to = append(to, &types.PRReviewer{User: convertUser(p.User)})
+	}
+	for _, u := range from.Reviewers {
+		if seen[u.AccountID] {
+			continue
+		}
+		seen[u.AccountID] = true
+		to = append(to, &types.PRReviewer{User: convertUser(u)})
+	}
+	return to
+}
+
+func convertUser(from user) scm.User {
+	return scm.User{
+		ID:   from.AccountID,
+		Name: from.DisplayName,
+	}
+}
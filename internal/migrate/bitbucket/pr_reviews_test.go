@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/harness-migrate/internal/types/enum"
+)
+
+func TestConvertPRReviews(t *testing.T) {
+	var in pullRequestParticipants
+	if err := json.Unmarshal([]byte(`{
+		"participants": [
+			{"user": {"account_id": "1", "display_name": "Jane"}, "role": "REVIEWER", "approved": true, "state": "approved"},
+			{"user": {"account_id": "2", "display_name": "John"}, "role": "PARTICIPANT", "state": "changes_requested"},
+			{"user": {"account_id": "3", "display_name": "Jim"}, "role": "PARTICIPANT"}
+		],
+		"reviewers": [{"account_id": "1"}, {"account_id": "4", "display_name": "Joe"}],
+		"source": {"commit": {"hash": "a1b2c3"}},
+		"updated_on": "2024-01-02T00:00:00Z"
+	}`), &in); err != nil {
+		t.Fatal(err)
+	}
+
+	reviews := convertPRReviews(&in)
+	if len(reviews) != 2 {
+		t.Fatalf("want 2 reviews, got %d", len(reviews))
+	}
+	if reviews[0].Author.ID != "1" || reviews[0].State != enum.ReviewDecisionApproved || reviews[0].Sha != "a1b2c3" {
+		t.Errorf("unexpected approval %+v", reviews[0])
+	}
+	if reviews[1].Author.ID != "2" || reviews[1].State != enum.ReviewDecisionChangeReq {
+		t.Errorf("unexpected review %+v", reviews[1])
+	}
+
+	reviewers := convertPRReviewers(&in)
+	if len(reviewers) != 2 || reviewers[0].ID != "1" || reviewers[1].Name != "Joe" {
+		t.Errorf("unexpected reviewers %+v", reviewers)
+	}
+}
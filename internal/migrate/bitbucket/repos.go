@@ -118,3 +118,22 @@ func (e *Export) GetLFSEnabledSettings(ctx context.Context, repoSlug string) (bo
 	// ref: https://jira.atlassian.com/browse/BCLOUD-20682
 	return true, nil
 }
+
+func (e *Export) GetRepoSettings(ctx context.Context, repoSlug string) (*types.RepoSettings, error) {
+	e.tracer.Start(common.MsgStartRepoSettings, repoSlug)
+	out := new(repository)
+	path := fmt.Sprintf("/2.0/repositories/%s?fields=description,website", repoSlug)
+	_, err := e.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		e.tracer.Stop(common.MsgCompleteRepoSettings, repoSlug)
+		return nil, err
+	}
+
+	// bitbucket cloud has no topics and does not expose the
+	// allowed merge strategies through the api.
+	e.tracer.Stop(common.MsgCompleteRepoSettings, repoSlug)
+	return &types.RepoSettings{
+		Description: out.Description,
+		Homepage:    out.Website,
+	}, nil
+}
@@ -44,6 +44,11 @@ type (
 		Detail  string `json:"detail"`
 	}
 
+	repository struct {
+		Description string `json:"description"`
+		Website     string `json:"website"`
+	}
+
 	comments struct {
 		pagination
 		Values []codeComment `json:"values"`
@@ -60,9 +65,16 @@ type (
 		Parent struct {
 			ID int `json:"id"`
 		} `json:"parent"`
-		User    user    `json:"user"`
-		Inline  *inline `json:"inline"`
-		Pending bool    `json:"pending"`
+		User       user        `json:"user"`
+		Inline     *inline     `json:"inline"`
+		Pending    bool        `json:"pending"`
+		Resolution *resolution `json:"resolution"`
+	}
+
+	// resolution represents the resolution of a comment thread.
+	resolution struct {
+		User      user      `json:"user"`
+		CreatedOn time.Time `json:"created_on"`
 	}
 
 	// user represents the user who made the comment.
@@ -85,6 +97,45 @@ type (
 		Outdated     bool   `json:"outdated"`
 	}
 
+	// pullRequestParticipants are the participants and the
+	// reviewers of a pull request.
+	pullRequestParticipants struct {
+		Participants []participant `json:"participants"`
+		Reviewers    []user        `json:"reviewers"`
+		Source       struct {
+			Commit struct {
+				Hash string `json:"hash"`
+			} `json:"commit"`
+		} `json:"source"`
+		UpdatedOn time.Time `json:"updated_on"`
+	}
+
+	participant struct {
+		User           user       `json:"user"`
+		Role           string     `json:"role"` // PARTICIPANT, REVIEWER
+		Approved       bool       `json:"approved"`
+		State          string     `json:"state"` // approved, changes_requested
+		ParticipatedOn *time.Time `json:"participated_on"`
+	}
+
+	// activities is a page of the pull request activity log,
+	// which holds updates, approvals and comments.
+	activities struct {
+		Values []struct {
+			Update *activityUpdate `json:"update"`
+		} `json:"values"`
+		pagination
+	}
+
+	// activityUpdate is a snapshot of the pull request state
+	// taken when it was updated.
+	activityUpdate struct {
+		State  string    `json:"state"` // OPEN, MERGED, DECLINED, SUPERSEDED
+		Draft  bool      `json:"draft"`
+		Author user      `json:"author"`
+		Date   time.Time `json:"date"`
+	}
+
 	rules struct {
 		Values []branchRule `json:"values"`
 		pagination
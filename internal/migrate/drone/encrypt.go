@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drone
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"unicode/utf8"
+)
+
+var (
+	errKeyLength           = errors.New("database secret must be 32 bytes")
+	errMalformedCiphertext = errors.New("malformed ciphertext")
+	errInvalidPlaintext    = errors.New("decrypted value is not valid utf-8")
+)
+
+// Decrypter decrypts secret values stored in the Drone database.
+type Decrypter interface {
+	Decrypt(ciphertext []byte) (string, error)
+}
+
+// NewDecrypter returns a Decrypter for secrets encrypted by Drone
+// using the DRONE_DATABASE_SECRET key. Drone uses AES-GCM with the
+// nonce prepended to the ciphertext.
+func NewDecrypter(key string) (Decrypter, error) {
+	if len(key) != 32 {
+		return nil, errKeyLength
+	}
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &aesgcm{gcm: gcm}, nil
+}
+
+type aesgcm struct {
+	gcm cipher.AEAD
+}
+
+func (d *aesgcm) Decrypt(ciphertext []byte) (string, error) {
+	size := d.gcm.NonceSize()
+	if len(ciphertext) < size {
+		return "", errMalformedCiphertext
+	}
+	plaintext, err := d.gcm.Open(nil, ciphertext[:size], ciphertext[size:], nil)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(plaintext) {
+		return "", errInvalidPlaintext
+	}
+	return string(plaintext), nil
+}
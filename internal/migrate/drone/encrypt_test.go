@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drone
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"testing"
+)
+
+const testKey = "fb4b4d6267c8a5ce8231f8b186dbca92"
+
+func encrypt(t *testing.T, key, plaintext string) []byte {
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	return gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+}
+
+func TestDecrypt(t *testing.T) {
+	d, err := NewDecrypter(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := d.Decrypt(encrypt(t, testKey, "correct-horse-battery-staple"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "correct-horse-battery-staple"; got != want {
+		t.Errorf("want decrypted value %q, got %q", want, got)
+	}
+}
+
+func TestDecrypt_WrongKey(t *testing.T) {
+	d, err := NewDecrypter(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext := encrypt(t, "00000000000000000000000000000000", "secret")
+	if _, err := d.Decrypt(ciphertext); err == nil {
+		t.Errorf("want error decrypting with the wrong key")
+	}
+}
+
+func TestDecrypt_Malformed(t *testing.T) {
+	d, err := NewDecrypter(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Decrypt([]byte("plain")); err != errMalformedCiphertext {
+		t.Errorf("want malformed ciphertext error, got %v", err)
+	}
+}
+
+func TestNewDecrypter_KeyLength(t *testing.T) {
+	if _, err := NewDecrypter("too-short"); err != errKeyLength {
+		t.Errorf("want key length error, got %v", err)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/drone/go-scm/scm"
 
 	"github.com/harness/harness-migrate/internal/migrate/drone/repo"
+	"github.com/harness/harness-migrate/internal/report"
 	"github.com/harness/harness-migrate/internal/tracer"
 	"github.com/harness/harness-migrate/internal/types"
 )
@@ -41,6 +42,14 @@ type Exporter struct {
 	ScmClient *scm.Client
 	ScmLogin  string
 
+	// Decrypter decrypts secret values when the Drone database
+	// is encrypted. Secrets are exported as-is when nil.
+	Decrypter Decrypter
+
+	// Report collects secrets that failed to decrypt, keyed by
+	// organization or project name.
+	Report map[string]*report.Report
+
 	Tracer tracer.Tracer
 }
 
@@ -70,7 +79,8 @@ func (m *Exporter) Export(ctx context.Context) (*types.Org, error) {
 		return nil, err
 	}
 	// map org secrets to common format
-	dstOrg.Secrets = convertOrgSecretsToSecrets(orgSecrets)
+	dstOrg.Secrets = m.convertOrgSecretsToSecrets(dstOrg.Name, orgSecrets)
+	m.reportSecrets(dstOrg.Name, len(dstOrg.Secrets))
 
 	m.Tracer.Stop("export organization secrets [done]")
 	m.Tracer.Stop("export organization %s [done]", repos[0].Namespace)
@@ -121,14 +131,19 @@ func (m *Exporter) Export(ctx context.Context) (*types.Org, error) {
 
 		// for each secret
 		for _, secret := range secrets {
+			value, err := m.decrypt(repo.Name, secret.Name, secret.Data)
+			if err != nil {
+				continue
+			}
 			dstSecret := &types.Secret{
 				Name:  secret.Name,
 				Desc:  secret.Name,
-				Value: secret.Data,
+				Value: value,
 			}
 			// append the secret to the project
 			dstProject.Secrets = append(dstProject.Secrets, dstSecret)
 		}
+		m.reportSecrets(repo.Name, len(dstProject.Secrets))
 
 		// append the project to the list of projects
 		dstOrg.Projects = append(dstOrg.Projects, dstProject)
@@ -138,18 +153,59 @@ func (m *Exporter) Export(ctx context.Context) (*types.Org, error) {
 	return dstOrg, nil
 }
 
-func convertOrgSecretsToSecrets(orgSecrets []*repo.OrgSecret) []*types.Secret {
-	secrets := make([]*types.Secret, len(orgSecrets))
-	for i, orgSecret := range orgSecrets {
+func (m *Exporter) convertOrgSecretsToSecrets(org string, orgSecrets []*repo.OrgSecret) []*types.Secret {
+	secrets := make([]*types.Secret, 0, len(orgSecrets))
+	for _, orgSecret := range orgSecrets {
+		value, err := m.decrypt(org, orgSecret.Name, orgSecret.Data)
+		if err != nil {
+			continue
+		}
 		secret := &types.Secret{
 			Name:  orgSecret.Name,
-			Value: string(orgSecret.Data),
+			Value: value,
 		}
-		secrets[i] = secret
+		secrets = append(secrets, secret)
 	}
 	return secrets
 }
 
+// decrypt returns the plaintext value of the secret. Secrets that
+// fail to decrypt are logged and reported under the owner name.
+func (m *Exporter) decrypt(owner, name string, data []byte) (string, error) {
+	if m.Decrypter == nil {
+		return string(data), nil
+	}
+	value, err := m.Decrypter.Decrypt(data)
+	if err != nil {
+		m.Tracer.Log("Skipping secret %s in %s: failed to decrypt: %s", name, owner, err.Error())
+		if r := m.report(owner); r != nil {
+			r.ReportError(report.ReportTypeSecrets, name, err.Error())
+		}
+		return "", err
+	}
+	return value, nil
+}
+
+// reportSecrets records the number of secrets exported for the owner.
+func (m *Exporter) reportSecrets(owner string, count int) {
+	if m.Decrypter == nil {
+		return
+	}
+	if r := m.report(owner); r != nil {
+		r.ReportMetric(report.ReportTypeSecrets, count)
+	}
+}
+
+func (m *Exporter) report(owner string) *report.Report {
+	if m.Report == nil {
+		return nil
+	}
+	if _, ok := m.Report[owner]; !ok {
+		m.Report[owner] = report.Init(owner)
+	}
+	return m.Report[owner]
+}
+
 func (m *Exporter) repositoryInList(repoName string) bool {
 	lowerRepoName := strings.ToLower(repoName)
 	for _, name := range m.RepositoryList {
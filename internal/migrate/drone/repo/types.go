@@ -90,7 +90,7 @@ type Secret struct {
 	ID              int64  `db:"secret_id"`
 	RepoID          int64  `db:"secret_repo_id"`
 	Name            string `db:"secret_name"`
-	Data            string `db:"secret_data"`
+	Data            []byte `db:"secret_data"`
 	PullRequest     bool   `db:"secret_pull_request"`
 	PullRequestPush bool   `db:"secret_pull_request_push"`
 	Created         int64  `db:"secret_created"`
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/harness/harness-migrate/internal/gitexporter"
+
+	"github.com/drone/go-scm/scm"
+)
+
+// hosts of images uploaded before the user-attachments storage. Files
+// on these hosts are downloaded without the credentials.
+var userImagesHosts = map[string]bool{
+	"user-images.githubusercontent.com":         true,
+	"private-user-images.githubusercontent.com": true,
+}
+
+// DownloadAttachment downloads files uploaded to pull requests and comments.
+func (e *Export) DownloadAttachment(ctx context.Context, repoSlug string, link string, limit int64) ([]byte, error) {
+	u, err := url.Parse(link)
+	if err != nil || u.Scheme != "https" && u.Scheme != "http" {
+		return nil, nil
+	}
+
+	if userImagesHosts[u.Host] {
+		return gitexporter.DownloadAttachment(ctx, &scm.Client{BaseURL: u}, link, limit)
+	}
+
+	if u.Host != e.webHost() {
+		return nil, nil
+	}
+	if !strings.HasPrefix(u.Path, "/user-attachments/") &&
+		!strings.HasPrefix(u.Path, "/storage/user/") &&
+		!strings.HasPrefix(u.Path, "/"+repoSlug+"/assets/") {
+		return nil, nil
+	}
+	return gitexporter.DownloadAttachment(ctx, e.github, link, limit)
+}
+
+// webHost returns the host of the web interface, which differs from the
+// api host on github.com.
+func (e *Export) webHost() string {
+	if e.github.BaseURL.Host == "api.github.com" {
+		return "github.com"
+	}
+	return e.github.BaseURL.Host
+}
@@ -48,6 +48,10 @@ type (
 		report     map[string]*report.Report
 
 		userMap map[string]types.User
+
+		// repoLabels holds the exported labels of each
+		// repository, keyed by repository slug.
+		repoLabels map[string]map[string]externalTypes.Label
 	}
 
 	graphQLRequest struct {
@@ -104,6 +108,31 @@ func (e *Export) ListRequestedReviewers(ctx context.Context, repoSlug string, pr
 	return reviewers, err
 }
 
+// ListPRs returns a page of pull requests, including the assignees,
+// milestone and auto merge state which go-scm does not decode.
+func (e *Export) ListPRs(
+	ctx context.Context,
+	repoSlug string,
+	opts scm.PullRequestListOptions,
+) ([]types.PRResponse, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls?%s", repoSlug, encodePullRequestListOptions(opts))
+	var out []*pullRequest
+	res, err := e.do(ctx, "GET", path, nil, &out)
+	return convertPullRequestList(out), res, err
+}
+
+func (e *Export) ListPRTimeline(
+	ctx context.Context,
+	repoSlug string,
+	prNumber int,
+	opts types.ListOptions,
+) ([]*timelineEvent, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues/%d/timeline?%s", repoSlug, prNumber, encodeListOptions(opts))
+	var out []*timelineEvent
+	res, err := e.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
 func (e *Export) GetUserByUserName(
 	ctx context.Context,
 	userName string,
@@ -216,6 +245,58 @@ func (e *Export) ListBranchRulesInternal(
 	return e.convertBranchRulesList(out, repoSlug), res, err
 }
 
+func (e *Export) ListPRReviewThreads(
+	ctx context.Context,
+	repoSlug string,
+	prNumber int,
+	opts types.ListOptions,
+) ([]reviewThread, *scm.Response, error) {
+	owner, name := scm.Split(repoSlug)
+	queryTemplate := `
+		{
+			repository(owner: "%s", name: "%s") {
+				pullRequest(number: %d) {
+					reviewThreads(first: %d%s) {
+						nodes {
+							isResolved
+							resolvedBy {
+								login
+							}
+							comments(first: 1) {
+								nodes {
+									databaseId
+								}
+							}
+						}
+						pageInfo {
+							endCursor
+							hasNextPage
+						}
+					}
+				}
+			}
+		}
+	`
+	var pagination string
+	if opts.Size == 0 {
+		opts.Size = common.DefaultLimit
+	}
+	if opts.URL != "" {
+		pagination = fmt.Sprintf(`, after: "%s"`, opts.URL)
+	}
+	query := fmt.Sprintf(queryTemplate, owner, name, prNumber, opts.Size, pagination)
+	body := graphQLRequest{
+		Query: query,
+	}
+	out := new(reviewThreadsResponse)
+	res, err := e.do(ctx, "POST", graphqlUrl, body, &out)
+	threads := out.Data.Repository.PullRequest.ReviewThreads
+	if err == nil && threads.PageInfo.HasNextPage {
+		res.Page.NextURL = threads.PageInfo.EndCursor
+	}
+	return threads.Nodes, res, err
+}
+
 func (e *Export) ListBranchRuleSets(
 	ctx context.Context,
 	repoSlug string,
@@ -250,6 +331,12 @@ func (e *Export) ListRepoLabels(
 }
 
 func (e *Export) do(ctx context.Context, method, path string, in, out interface{}) (*scm.Response, error) {
+	return do(ctx, e.github, method, path, in, out)
+}
+
+// do executes a github api request using the scm client
+// transport and records the rate limit details.
+func do(ctx context.Context, client *scm.Client, method, path string, in, out interface{}) (*scm.Response, error) {
 	req := &scm.Request{
 		Method: method,
 		Path:   path,
@@ -266,7 +353,7 @@ func (e *Export) do(ctx context.Context, method, path string, in, out interface{
 	}
 
 	// execute the http request
-	res, err := e.github.Do(ctx, req)
+	res, err := client.Do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -287,7 +374,7 @@ func (e *Export) do(ctx context.Context, method, path string, in, out interface{
 	)
 
 	// snapshot the request rate limit
-	e.github.SetRate(res.Rate)
+	client.SetRate(res.Rate)
 
 	if res.Rate.Remaining == 0 {
 		return res, fmt.Errorf("Github rate limit has been reached. please wait for %d until try again.", res.Rate.Reset)
@@ -21,6 +21,7 @@ import (
 	"github.com/harness/harness-migrate/internal/report"
 	"github.com/harness/harness-migrate/internal/tracer"
 	"github.com/harness/harness-migrate/internal/types"
+	externalTypes "github.com/harness/harness-migrate/types"
 
 	"github.com/drone/go-scm/scm"
 )
@@ -51,5 +52,6 @@ func New(
 		userMap:           ckpt,
 		fileLogger:        logger,
 		report:            report,
+		repoLabels:        make(map[string]map[string]externalTypes.Label),
 	}
 }
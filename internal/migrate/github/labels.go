@@ -18,6 +18,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"path"
 
 	"github.com/harness/harness-migrate/internal/checkpoint"
 	"github.com/harness/harness-migrate/internal/common"
@@ -58,6 +59,7 @@ func (e *Export) ListLabels(
 
 	// all pages done
 	if checkpointPage == -1 {
+		e.repoLabels[repoSlug] = allLabels
 		return allLabels, nil
 	}
 
@@ -92,5 +94,48 @@ func (e *Export) ListLabels(
 		e.tracer.LogError(common.ErrCheckpointPrPageSave, err)
 	}
 
+	e.repoLabels[repoSlug] = allLabels
 	return allLabels, nil
 }
+
+// ListSpaceLabels returns the labels of the organization.
+// Github has no api for organization labels, so the labels
+// defined identically in all exported repositories of the
+// organization are returned.
+func (e *Export) ListSpaceLabels(
+	ctx context.Context,
+	namespace string,
+	opts types.ListOptions,
+) (map[string]externalTypes.Label, error) {
+	return commonLabels(e.repoLabels, namespace), nil
+}
+
+// helper function returns the labels which are identical in
+// all repositories of the namespace. Labels of a namespace
+// with a single repository stay with the repository.
+func commonLabels(repoLabels map[string]map[string]externalTypes.Label, namespace string) map[string]externalTypes.Label {
+	var shared map[string]externalTypes.Label
+	repos := 0
+	for repoSlug, labels := range repoLabels {
+		if path.Dir(repoSlug) != namespace {
+			continue
+		}
+		repos++
+		if shared == nil {
+			shared = make(map[string]externalTypes.Label, len(labels))
+			for name, label := range labels {
+				shared[name] = label
+			}
+			continue
+		}
+		for name, label := range shared {
+			if other, ok := labels[name]; !ok || other != label {
+				delete(shared, name)
+			}
+		}
+	}
+	if repos < 2 {
+		return nil
+	}
+	return shared
+}
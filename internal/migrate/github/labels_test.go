@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"testing"
+
+	externalTypes "github.com/harness/harness-migrate/types"
+)
+
+func TestCommonLabels(t *testing.T) {
+	bug := externalTypes.Label{Name: "bug", Color: "red"}
+	docs := externalTypes.Label{Name: "docs", Color: "blue"}
+	repoLabels := map[string]map[string]externalTypes.Label{
+		"octocat/hello": {"bug": bug, "docs": docs},
+		"octocat/world": {"bug": bug, "docs": {Name: "docs", Color: "green"}},
+		"other/single":  {"bug": bug},
+	}
+
+	got := commonLabels(repoLabels, "octocat")
+	if len(got) != 1 || got["bug"] != bug {
+		t.Errorf("Want only the identical label bug, got %v", got)
+	}
+	if got := commonLabels(repoLabels, "other"); got != nil {
+		t.Errorf("Want no space labels for a single repository, got %v", got)
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/harness/harness-migrate/internal/common"
+	"github.com/harness/harness-migrate/internal/tracer"
+	"github.com/harness/harness-migrate/internal/types"
+
+	"github.com/drone/go-convert/convert/github"
+	"github.com/drone/go-scm/scm"
+)
+
+// workflowsDir is the folder github actions workflows are
+// read from.
+const workflowsDir = ".github/workflows"
+
+type (
+	// PipelineExporter exports github actions workflows,
+	// secrets and variables of an organization.
+	PipelineExporter struct {
+		Github         *scm.Client
+		Org            string
+		RepositoryList []string
+
+		DockerConn string
+		KubeName   string
+		KubeConn   string
+
+		Tracer tracer.Tracer
+	}
+
+	actionsSecret struct {
+		Name string `json:"name"`
+	}
+
+	actionsSecretList struct {
+		TotalCount int              `json:"total_count"`
+		Secrets    []*actionsSecret `json:"secrets"`
+	}
+
+	actionsVariable struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	actionsVariableList struct {
+		TotalCount int                `json:"total_count"`
+		Variables  []*actionsVariable `json:"variables"`
+	}
+)
+
+// Export exports the github actions workflows of every
+// repository in the organization. Repositories without
+// workflows are skipped. Secret values cannot be read
+// from github and are exported as placeholders.
+func (m *PipelineExporter) Export(ctx context.Context) (*types.Org, error) {
+	m.Tracer.Start("export organization %s", m.Org)
+
+	dstOrg := &types.Org{
+		Name: m.Org,
+	}
+
+	secrets, err := m.listSecrets(ctx, fmt.Sprintf("orgs/%s/actions/secrets", m.Org))
+	if err != nil {
+		m.Tracer.Stop("Failed to export organization secrets: %s", err.Error())
+		return nil, err
+	}
+	dstOrg.Secrets = secrets
+
+	variables, err := m.listVariables(ctx, fmt.Sprintf("orgs/%s/actions/variables", m.Org))
+	if err != nil {
+		m.Tracer.Stop("Failed to export organization variables: %s", err.Error())
+		return nil, err
+	}
+	dstOrg.Variables = variables
+
+	m.Tracer.Stop("export organization %s [done]", m.Org)
+
+	repos, err := m.listRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, repo := range repos {
+		// Skip repositories that are not in the m.RepositoryList
+		if len(m.RepositoryList) > 0 && !m.repositoryInList(repo.Name) {
+			continue
+		}
+		if repo.Archived {
+			m.Tracer.Log("Skipping repository %s: repository is archived.", repo.Name)
+			continue
+		}
+
+		m.Tracer.Start("export project %s", repo.Name)
+
+		pipelines, err := m.listPipelines(ctx, repo)
+		if err != nil {
+			return nil, err
+		}
+		if len(pipelines) == 0 {
+			m.Tracer.Stop("Skipping repository %s: no workflows found.", repo.Name)
+			continue
+		}
+
+		dstProject := &types.Project{
+			Name:      repo.Name,
+			Type:      "github",
+			Repo:      repo.Clone,
+			Branch:    repo.Branch,
+			Pipelines: pipelines,
+		}
+
+		dstProject.Secrets, err = m.listSecrets(ctx, fmt.Sprintf("repos/%s/actions/secrets", scm.Join(repo.Namespace, repo.Name)))
+		if err != nil {
+			return nil, err
+		}
+		dstProject.Variables, err = m.listVariables(ctx, fmt.Sprintf("repos/%s/actions/variables", scm.Join(repo.Namespace, repo.Name)))
+		if err != nil {
+			return nil, err
+		}
+
+		dstOrg.Projects = append(dstOrg.Projects, dstProject)
+
+		m.Tracer.Stop("export project %s [done]", repo.Name)
+	}
+
+	return dstOrg, nil
+}
+
+// listRepositories returns all repositories in the organization.
+func (m *PipelineExporter) listRepositories(ctx context.Context) ([]*scm.Repository, error) {
+	var repos []*scm.Repository
+	opts := scm.ListOptions{Size: common.DefaultLimit}
+	for {
+		result, res, err := m.Github.Repositories.ListNamespace(ctx, m.Org, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repos for org %s: %w", m.Org, err)
+		}
+		repos = append(repos, result...)
+		if res.Page.Next == 0 {
+			break
+		}
+		opts.Page = res.Page.Next
+	}
+	return repos, nil
+}
+
+// listPipelines reads and converts every workflow of the
+// repository default branch.
+func (m *PipelineExporter) listPipelines(ctx context.Context, repo *scm.Repository) ([]*types.Pipeline, error) {
+	repoSlug := scm.Join(repo.Namespace, repo.Name)
+	files, res, err := m.Github.Contents.List(ctx, repoSlug, workflowsDir, repo.Branch, scm.ListOptions{})
+	if err != nil {
+		if res != nil && res.Status == 404 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list workflows for repo %s: %w", repoSlug, err)
+	}
+
+	var pipelines []*types.Pipeline
+	for _, file := range files {
+		ext := path.Ext(file.Path)
+		if file.Kind != scm.ContentKindFile || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+
+		content, _, err := m.Github.Contents.Find(ctx, repoSlug, file.Path, repo.Branch)
+		if err != nil {
+			m.Tracer.Log("Skipping workflow %s in %s: %s", file.Path, repo.Name, err.Error())
+			continue
+		}
+
+		converter := github.New(
+			github.WithDockerhub(m.DockerConn),
+			github.WithKubernetes(m.KubeName, m.KubeConn),
+		)
+		converted, err := converter.ConvertBytes(content.Data)
+		if err != nil {
+			m.Tracer.Log("Skipping workflow %s in %s: failed to convert: %s", file.Path, repo.Name, err.Error())
+			continue
+		}
+
+		pipelines = append(pipelines, &types.Pipeline{
+			Name:   strings.TrimSuffix(path.Base(file.Path), ext),
+			Repo:   repo.Clone,
+			Branch: repo.Branch,
+			Type:   "github",
+			Yaml:   string(converted),
+		})
+	}
+	return pipelines, nil
+}
+
+// listSecrets returns the actions secrets at the given api
+// path as placeholders, since github never returns secret
+// values.
+func (m *PipelineExporter) listSecrets(ctx context.Context, path string) ([]*types.Secret, error) {
+	var secrets []*types.Secret
+	opts := types.ListOptions{Page: 1}
+	for {
+		out := new(actionsSecretList)
+		if _, err := do(ctx, m.Github, "GET", path+"?"+encodeListOptions(opts), nil, out); err != nil {
+			return nil, fmt.Errorf("failed to list secrets %s: %w", path, err)
+		}
+		for _, src := range out.Secrets {
+			secrets = append(secrets, &types.Secret{
+				Name:        src.Name,
+				Desc:        src.Name,
+				Placeholder: true,
+			})
+		}
+		if len(out.Secrets) == 0 || len(secrets) >= out.TotalCount {
+			break
+		}
+		opts.Page++
+	}
+	return secrets, nil
+}
+
+// listVariables returns the actions variables at the given
+// api path.
+func (m *PipelineExporter) listVariables(ctx context.Context, path string) ([]*types.Variable, error) {
+	var variables []*types.Variable
+	opts := types.ListOptions{Page: 1}
+	for {
+		out := new(actionsVariableList)
+		if _, err := do(ctx, m.Github, "GET", path+"?"+encodeListOptions(opts), nil, out); err != nil {
+			return nil, fmt.Errorf("failed to list variables %s: %w", path, err)
+		}
+		for _, src := range out.Variables {
+			variables = append(variables, &types.Variable{
+				Name:  src.Name,
+				Value: src.Value,
+			})
+		}
+		if len(out.Variables) == 0 || len(variables) >= out.TotalCount {
+			break
+		}
+		opts.Page++
+	}
+	return variables, nil
+}
+
+func (m *PipelineExporter) repositoryInList(repoName string) bool {
+	lowerRepoName := strings.ToLower(repoName)
+	for _, name := range m.RepositoryList {
+		if strings.ToLower(name) == lowerRepoName {
+			return true
+		}
+	}
+	return false
+}
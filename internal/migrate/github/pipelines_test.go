@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/harness/harness-migrate/internal/tracer"
+
+	scmgithub "github.com/drone/go-scm/scm/driver/github"
+	"github.com/h2non/gock"
+)
+
+const testWorkflow = `name: ci
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: go test ./...
+`
+
+func TestPipelineExport(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/orgs/octocat/actions/secrets").
+		Reply(200).
+		SetHeader("X-RateLimit-Remaining", "5000").
+		JSON(map[string]interface{}{
+			"total_count": 1,
+			"secrets":     []map[string]string{{"name": "ORG_TOKEN"}},
+		})
+	gock.New("https://api.github.com").
+		Get("/orgs/octocat/actions/variables").
+		Reply(200).
+		SetHeader("X-RateLimit-Remaining", "5000").
+		JSON(map[string]interface{}{
+			"total_count": 1,
+			"variables":   []map[string]string{{"name": "REGION", "value": "us-east-1"}},
+		})
+	gock.New("https://api.github.com").
+		Get("/orgs/octocat/repos").
+		Reply(200).
+		SetHeader("X-RateLimit-Remaining", "5000").
+		JSON([]map[string]interface{}{
+			{"name": "hello-world", "full_name": "octocat/hello-world", "default_branch": "main", "owner": map[string]string{"login": "octocat"}},
+			{"name": "no-workflows", "full_name": "octocat/no-workflows", "default_branch": "main", "owner": map[string]string{"login": "octocat"}},
+		})
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/contents/.github/workflows").
+		Reply(200).
+		SetHeader("X-RateLimit-Remaining", "5000").
+		JSON([]map[string]string{
+			{"path": ".github/workflows/ci.yml", "type": "file"},
+			{"path": ".github/workflows/README.md", "type": "file"},
+		})
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/contents/.github/workflows/ci.yml").
+		Reply(200).
+		SetHeader("X-RateLimit-Remaining", "5000").
+		JSON(map[string]string{
+			"path":     ".github/workflows/ci.yml",
+			"type":     "file",
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString([]byte(testWorkflow)),
+		})
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/actions/secrets").
+		Reply(200).
+		SetHeader("X-RateLimit-Remaining", "5000").
+		JSON(map[string]interface{}{
+			"total_count": 1,
+			"secrets":     []map[string]string{{"name": "DEPLOY_KEY"}},
+		})
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/actions/variables").
+		Reply(200).
+		SetHeader("X-RateLimit-Remaining", "5000").
+		JSON(map[string]interface{}{"total_count": 0})
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/no-workflows/contents/.github/workflows").
+		Reply(404).
+		JSON(map[string]string{"message": "Not Found"})
+
+	exporter := &PipelineExporter{
+		Github: scmgithub.NewDefault(),
+		Org:    "octocat",
+		Tracer: tracer.Default(),
+	}
+	org, err := exporter.Export(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(org.Secrets), 1; got != want {
+		t.Fatalf("want %d org secrets, got %d", want, got)
+	}
+	if !org.Secrets[0].Placeholder {
+		t.Errorf("want org secret exported as placeholder")
+	}
+	if got, want := len(org.Variables), 1; got != want {
+		t.Fatalf("want %d org variables, got %d", want, got)
+	}
+	if got, want := org.Variables[0].Value, "us-east-1"; got != want {
+		t.Errorf("want variable value %q, got %q", want, got)
+	}
+	if got, want := len(org.Projects), 1; got != want {
+		t.Fatalf("want %d projects, got %d", want, got)
+	}
+
+	project := org.Projects[0]
+	if got, want := project.Name, "hello-world"; got != want {
+		t.Errorf("want project name %q, got %q", want, got)
+	}
+	if got, want := len(project.Pipelines), 1; got != want {
+		t.Fatalf("want %d pipelines, got %d", want, got)
+	}
+	if got, want := project.Pipelines[0].Name, "ci"; got != want {
+		t.Errorf("want pipeline name %q, got %q", want, got)
+	}
+	if project.Pipelines[0].Yaml == "" {
+		t.Errorf("want converted pipeline yaml")
+	}
+	if got, want := len(project.Secrets), 1; got != want {
+		t.Fatalf("want %d project secrets, got %d", want, got)
+	}
+	if got, want := project.Secrets[0].Name, "DEPLOY_KEY"; got != want {
+		t.Errorf("want secret name %q, got %q", want, got)
+	}
+	if !gock.IsDone() {
+		t.Errorf("pending mocks: %v", gock.Pending())
+	}
+}
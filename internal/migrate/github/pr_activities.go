@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/harness-migrate/internal/checkpoint"
+	"github.com/harness/harness-migrate/internal/common"
+	"github.com/harness/harness-migrate/internal/types"
+)
+
+func (e *Export) ListPullRequestActivities(
+	ctx context.Context,
+	repoSlug string,
+	prNumber int,
+) ([]*types.PRActivity, error) {
+	e.tracer.Debug().Start(common.MsgStartExportPrActivities, repoSlug, prNumber)
+	var allActivities []*types.PRActivity
+	msgActivitiesExport := common.MsgCompleteExportPrActivities
+	defer func() {
+		e.tracer.Debug().Stop(msgActivitiesExport, len(allActivities), repoSlug, prNumber)
+	}()
+
+	checkpointDataKey := fmt.Sprintf(common.PRActivityCheckpointData, repoSlug, prNumber)
+	val, ok, err := checkpoint.GetCheckpointData[[]*types.PRActivity](e.checkpointManager, checkpointDataKey)
+	if err != nil {
+		e.tracer.LogError(common.ErrCheckpointDataRead, err)
+		panic(common.PanicCheckpointSaveErr)
+	}
+	if ok && val != nil {
+		msgActivitiesExport = common.MsgCheckpointLoadPRActivities
+		allActivities = val
+		return allActivities, nil
+	}
+
+	// the timeline is converted once all pages are listed, since
+	// the close reported with a merge can be on the next page.
+	var events []*timelineEvent
+	opts := types.ListOptions{Page: 1, Size: 100}
+	for {
+		page, _, err := e.ListPRTimeline(ctx, repoSlug, prNumber, opts)
+		if err != nil {
+			e.tracer.LogError(common.ErrListActivities, repoSlug, prNumber, err)
+			return nil, fmt.Errorf(common.ErrListActivities, repoSlug, prNumber, err)
+		}
+		events = append(events, page...)
+
+		if len(page) < opts.Size {
+			break
+		}
+		opts.Page++
+	}
+
+	activities := convertPRActivities(events)
+	for _, activity := range activities {
+		email, err := e.FindEmailByUsername(ctx, activity.Actor.Login)
+		if err != nil {
+			return nil, fmt.Errorf("cannot find email for actor %s: %w", activity.Actor.Login, err)
+		}
+		activity.Actor.Email = email
+	}
+	allActivities = activities
+
+	err = e.checkpointManager.SaveCheckpoint(checkpointDataKey, allActivities)
+	if err != nil {
+		e.tracer.LogError(common.ErrCheckpointPrActivitiesDataSave, err)
+	}
+
+	return allActivities, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/harness/harness-migrate/internal/types"
+	externalTypes "github.com/harness/harness-migrate/types"
+
+	"github.com/drone/go-scm/scm"
+)
+
+// activityTypes maps the timeline events to activity types,
+// events not in the map are not exported.
+var activityTypes = map[string]externalTypes.ActivityType{
+	"closed":                externalTypes.ActivityClosed,
+	"reopened":              externalTypes.ActivityReopened,
+	"merged":                externalTypes.ActivityMerged,
+	"head_ref_force_pushed": externalTypes.ActivityForcePushed,
+	"ready_for_review":      externalTypes.ActivityReadyForReview,
+	"convert_to_draft":      externalTypes.ActivityDraft,
+}
+
+// convertPRActivities converts the timeline events to activities. Github
+// emits a closed event next to the merged event of a merged pull request,
+// the closed event of the same actor at the same time is dropped.
+func convertPRActivities(from []*timelineEvent) []*types.PRActivity {
+	merged := make(map[string]bool)
+	for _, event := range from {
+		if event.Event == "merged" {
+			merged[eventKey(event)] = true
+		}
+	}
+
+	var to []*types.PRActivity
+	for _, event := range from {
+		activityType, ok := activityTypes[event.Event]
+		if !ok {
+			continue
+		}
+		if event.Event == "closed" && merged[eventKey(event)] {
+			continue
+		}
+
+		activity := &types.PRActivity{
+			Type:    activityType,
+			Created: event.CreatedAt,
+			To:      event.CommitID,
+		}
+		if event.Actor != nil {
+			activity.Actor = scm.User{
+				ID:     strconv.Itoa(event.Actor.ID),
+				Login:  event.Actor.Login,
+				Avatar: event.Actor.AvatarURL,
+			}
+		}
+		to = append(to, activity)
+	}
+	return to
+}
+
+// helper function returns the actor and time of the event.
+func eventKey(event *timelineEvent) string {
+	var login string
+	if event.Actor != nil {
+		login = event.Actor.Login
+	}
+	return login + "@" + event.CreatedAt.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"testing"
+	"time"
+
+	externalTypes "github.com/harness/harness-migrate/types"
+)
+
+func TestConvertPRActivities(t *testing.T) {
+	merged := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	octocat := &user{ID: 1, Login: "octocat"}
+	events := []*timelineEvent{
+		{Event: "closed", Actor: octocat, CreatedAt: merged.Add(-time.Hour)},
+		{Event: "reopened", Actor: octocat, CreatedAt: merged.Add(-time.Minute)},
+		{Event: "commented", Actor: octocat, CreatedAt: merged.Add(-time.Second)},
+		{Event: "merged", Actor: octocat, CreatedAt: merged, CommitID: "a1b2c3"},
+		{Event: "closed", Actor: octocat, CreatedAt: merged},
+	}
+
+	got := convertPRActivities(events)
+	want := []externalTypes.ActivityType{
+		externalTypes.ActivityClosed,
+		externalTypes.ActivityReopened,
+		externalTypes.ActivityMerged,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("want %d activities, got %d", len(want), len(got))
+	}
+	for i, activity := range got {
+		if activity.Type != want[i] {
+			t.Errorf("want activity %s, got %s", want[i], activity.Type)
+		}
+	}
+	if got[2].To != "a1b2c3" || got[2].Actor.Login != "octocat" {
+		t.Errorf("unexpected merge activity %+v", got[2])
+	}
+}
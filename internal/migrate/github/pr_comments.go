@@ -17,6 +17,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/drone/go-scm/scm"
 	"github.com/harness/harness-migrate/internal/checkpoint"
@@ -118,6 +119,14 @@ func (e *Export) ListPullRequestComments(
 		opts.Page += 1
 	}
 
+	if err := e.addResolutionToComments(ctx, repoSlug, prNumber, allComments); err != nil {
+		return nil, err
+	}
+	err = e.checkpointManager.SaveCheckpoint(checkpointDataKey, allComments)
+	if err != nil {
+		e.tracer.LogError(common.ErrCheckpointPrCommentsDataSave, err)
+	}
+
 	err = e.checkpointManager.SaveCheckpoint(checkpointPageKey, -1)
 	if err != nil {
 		e.tracer.LogError(common.ErrCheckpointPrCommentsPageSave)
@@ -126,6 +135,63 @@ func (e *Export) ListPullRequestComments(
 	return allComments, nil
 }
 
+// addResolutionToComments sets the resolution of the resolved review
+// threads on their first comment. Github does not expose when a thread
+// was resolved, the last update of the thread is used instead.
+func (e *Export) addResolutionToComments(ctx context.Context, repoSlug string, prNumber int, comments []*types.PRComment) error {
+	byID := make(map[int]*types.PRComment, len(comments))
+	for _, comment := range comments {
+		byID[comment.ID] = comment
+	}
+
+	opts := types.ListOptions{Size: common.DefaultLimit}
+	for {
+		threads, res, err := e.ListPRReviewThreads(ctx, repoSlug, prNumber, opts)
+		if err != nil {
+			e.tracer.LogError(common.ErrListComments, repoSlug, prNumber, err)
+			return fmt.Errorf(common.ErrListComments, repoSlug, prNumber, err)
+		}
+
+		for _, thread := range threads {
+			if !thread.IsResolved || thread.ResolvedBy == nil || len(thread.Comments.Nodes) == 0 {
+				continue
+			}
+			root, ok := byID[thread.Comments.Nodes[0].DatabaseID]
+			if !ok {
+				continue
+			}
+			email, err := e.FindEmailByUsername(ctx, thread.ResolvedBy.Login)
+			if err != nil {
+				return fmt.Errorf("cannot find email for resolver %s: %w", thread.ResolvedBy.Login, err)
+			}
+			root.Resolution = &types.Resolution{
+				Resolved: lastThreadUpdate(root, comments),
+				ResolvedBy: scm.User{
+					Login: thread.ResolvedBy.Login,
+					Email: email,
+				},
+			}
+		}
+
+		if res.Page.NextURL == "" {
+			return nil
+		}
+		opts.URL = res.Page.NextURL
+	}
+}
+
+// helper function returns the last update of the thread
+// started by the root comment.
+func lastThreadUpdate(root *types.PRComment, comments []*types.PRComment) time.Time {
+	updated := root.Updated
+	for _, comment := range comments {
+		if comment.ParentID == root.ID && comment.Updated.After(updated) {
+			updated = comment.Updated
+		}
+	}
+	return updated
+}
+
 func (e *Export) addEmailToAuthorInComments(ctx context.Context, comments []*types.PRComment) ([]*types.PRComment, error) {
 	commentsCopy := make([]*types.PRComment, len(comments))
 	for i, comment := range comments {
@@ -17,6 +17,8 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
 
 	"github.com/harness/harness-migrate/internal/checkpoint"
 	"github.com/harness/harness-migrate/internal/common"
@@ -68,13 +70,12 @@ func (e *Export) ListPullRequests(
 	}
 
 	for {
-		prs, resp, err := e.github.PullRequests.List(ctx, repoSlug, opts)
+		prs, _, err := e.ListPRs(ctx, repoSlug, opts)
 		if err != nil {
 			e.tracer.LogError(common.ErrListPr, err)
 			return nil, fmt.Errorf("cannot list prs: %w", err)
 		}
-		mappedPrs := common.MapPullRequest(prs)
-		mappedPrsWithAuthor, err := e.addEmailToPRAuthor(ctx, mappedPrs)
+		mappedPrsWithAuthor, err := e.addEmailToPRAuthor(ctx, prs)
 		if err != nil {
 			return nil, fmt.Errorf("cannot add email to author: %w", err)
 		}
@@ -85,15 +86,21 @@ func (e *Export) ListPullRequests(
 			e.tracer.LogError(common.ErrCheckpointPrDataSave, err)
 		}
 
-		err = e.checkpointManager.SaveCheckpoint(checkpointPageKey, resp.Page.Next)
+		// the pagination links are not parsed, a page with
+		// less pull requests than the page size is the last.
+		next := opts.Page + 1
+		if len(prs) == 0 || len(prs) < opts.Size {
+			next = 0
+		}
+		err = e.checkpointManager.SaveCheckpoint(checkpointPageKey, next)
 		if err != nil {
 			e.tracer.LogError(common.ErrCheckpointPrPageSave, err)
 		}
 
-		if resp.Page.Next == 0 {
+		if next == 0 {
 			break
 		}
-		opts.Page = resp.Page.Next
+		opts.Page = next
 	}
 
 	err = e.checkpointManager.SaveCheckpoint(checkpointPageKey, -1)
@@ -111,7 +118,31 @@ func (e *Export) addEmailToPRAuthor(ctx context.Context, prs []types.PRResponse)
 			return nil, fmt.Errorf("cannot find email for author %s: %w", pr.Author.Login, err)
 		}
 		pr.Author.Email = email
+
+		for j, assignee := range pr.Assignees {
+			email, err := e.FindEmailByUsername(ctx, assignee.Login)
+			if err != nil {
+				return nil, fmt.Errorf("cannot find email for assignee %s: %w", assignee.Login, err)
+			}
+			pr.Assignees[j].Email = email
+		}
 		prs[i] = pr
 	}
 	return prs, nil
 }
+
+func encodePullRequestListOptions(opts scm.PullRequestListOptions) string {
+	params := url.Values{}
+	if opts.Page != 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Size != 0 {
+		params.Set("per_page", strconv.Itoa(opts.Size))
+	}
+	if opts.Open && opts.Closed {
+		params.Set("state", "all")
+	} else if opts.Closed {
+		params.Set("state", "closed")
+	}
+	return params.Encode()
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/harness/harness-migrate/internal/types"
+
+	"github.com/drone/go-scm/scm"
+)
+
+func convertPullRequestList(from []*pullRequest) []types.PRResponse {
+	to := []types.PRResponse{}
+	for _, v := range from {
+		to = append(to, convertPullRequest(v))
+	}
+	return to
+}
+
+// convertPullRequest converts the pull request like go-scm, and
+// adds the assignees, milestone and auto merge state.
+func convertPullRequest(from *pullRequest) types.PRResponse {
+	var labels []scm.Label
+	for _, label := range from.Labels {
+		labels = append(labels, scm.Label{
+			Name:  label.Name,
+			Color: label.Color,
+		})
+	}
+	var fork string
+	if from.Head.Repo != nil {
+		fork = from.Head.Repo.FullName
+	}
+	to := types.PRResponse{
+		PullRequest: scm.PullRequest{
+			Number: from.Number,
+			Title:  from.Title,
+			Body:   from.Body,
+			Sha:    from.Head.Sha,
+			Ref:    fmt.Sprintf("refs/pull/%d/head", from.Number),
+			Source: from.Head.Ref,
+			Target: from.Base.Ref,
+			Fork:   fork,
+			Link:   from.HTMLURL,
+			Diff:   from.DiffURL,
+			Draft:  from.Draft,
+			Closed: from.State != "open",
+			Merged: from.MergedAt != nil,
+			Head: scm.Reference{
+				Name: from.Head.Ref,
+				Path: scm.ExpandRef(from.Head.Ref, "refs/heads"),
+				Sha:  from.Head.Sha,
+			},
+			Base: scm.Reference{
+				Name: from.Base.Ref,
+				Path: scm.ExpandRef(from.Base.Ref, "refs/heads"),
+				Sha:  from.Base.Sha,
+			},
+			Author: scm.User{
+				Login:  from.User.Login,
+				Avatar: from.User.AvatarURL,
+			},
+			Created: from.CreatedAt,
+			Updated: from.UpdatedAt,
+			Labels:  labels,
+		},
+		AutoMerge: from.AutoMerge != nil,
+	}
+	for _, assignee := range from.Assignees {
+		to.Assignees = append(to.Assignees, scm.User{
+			ID:     strconv.Itoa(assignee.ID),
+			Login:  assignee.Login,
+			Avatar: assignee.AvatarURL,
+		})
+	}
+	if from.Milestone != nil {
+		to.Milestone = from.Milestone.Title
+	}
+	return to
+}
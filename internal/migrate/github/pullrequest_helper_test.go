@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestConvertPullRequest(t *testing.T) {
+	var prs []*pullRequest
+	raw, _ := os.ReadFile("testdata/pulls.json")
+	if err := json.Unmarshal(raw, &prs); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	got := convertPullRequestList(prs)
+	if len(got) != 2 {
+		t.Fatalf("Want 2 pull requests, got %d", len(got))
+	}
+
+	merged := got[0]
+	if !merged.Closed || !merged.Merged || merged.Fork != "octocat/hello-world" || merged.Ref != "refs/pull/1347/head" {
+		t.Errorf("Unexpected pull request %+v", merged.PullRequest)
+	}
+	if len(merged.Assignees) != 1 || merged.Assignees[0].Login != "hubot" || merged.Assignees[0].ID != "2" {
+		t.Errorf("Unexpected assignees %+v", merged.Assignees)
+	}
+	if merged.Milestone != "v1.0" || !merged.AutoMerge {
+		t.Errorf("Want milestone and auto merge, got %q and %v", merged.Milestone, merged.AutoMerge)
+	}
+
+	open := got[1]
+	if open.Closed || open.Merged || open.Fork != "" || open.Milestone != "" || open.AutoMerge {
+		t.Errorf("Unexpected open pull request %+v", open)
+	}
+}
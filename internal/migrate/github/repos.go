@@ -117,3 +117,39 @@ func (e *Export) GetLFSEnabledSettings(ctx context.Context, repoSlug string) (bo
 	// Github has Git LFS enabled.
 	return true, nil
 }
+
+func (e *Export) GetRepoSettings(ctx context.Context, repoSlug string) (*types.RepoSettings, error) {
+	e.tracer.Start(common.MsgStartRepoSettings, repoSlug)
+	out := new(repository)
+	_, err := e.do(ctx, "GET", fmt.Sprintf("repos/%s", repoSlug), nil, out)
+	if err != nil {
+		e.tracer.Stop(common.MsgCompleteRepoSettings, repoSlug)
+		return nil, err
+	}
+
+	e.tracer.Stop(common.MsgCompleteRepoSettings, repoSlug)
+	return convertRepoSettings(out), nil
+}
+
+func convertRepoSettings(from *repository) *types.RepoSettings {
+	var strategies []string
+	if from.AllowMergeCommit {
+		strategies = append(strategies, "merge")
+	}
+	if from.AllowSquashMerge {
+		strategies = append(strategies, "squash")
+	}
+	if from.AllowRebaseMerge {
+		strategies = append(strategies, "rebase")
+	}
+	return &types.RepoSettings{
+		Description: from.Description,
+		Topics:      from.Topics,
+		Homepage:    from.Homepage,
+		Merge: &types.MergeSettings{
+			StrategiesAllowed: strategies,
+			DeleteBranch:      from.DeleteBranchOnMerge,
+			TitleFormat:       from.MergeCommitTitle,
+		},
+	}
+}
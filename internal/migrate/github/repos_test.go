@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package github
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/harness/harness-migrate/internal/types"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertRepoSettings(t *testing.T) {
+	raw, err := os.ReadFile("testdata/repo.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := new(repository)
+	if err := json.Unmarshal(raw, in); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &types.RepoSettings{
+		Description: "This your first repo!",
+		Topics:      []string{"octocat", "atom", "electron"},
+		Homepage:    "https://github.com",
+		Merge: &types.MergeSettings{
+			StrategiesAllowed: []string{"merge", "squash"},
+			DeleteBranch:      true,
+			TitleFormat:       "PR_TITLE",
+		},
+	}
+	got := convertRepoSettings(in)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected repository settings")
+		t.Log(diff)
+	}
+}
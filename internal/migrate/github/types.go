@@ -22,6 +22,17 @@ type (
 		Message string `json:"message"`
 	}
 
+	repository struct {
+		Description         string   `json:"description"`
+		Homepage            string   `json:"homepage"`
+		Topics              []string `json:"topics"`
+		AllowMergeCommit    bool     `json:"allow_merge_commit"`
+		AllowSquashMerge    bool     `json:"allow_squash_merge"`
+		AllowRebaseMerge    bool     `json:"allow_rebase_merge"`
+		DeleteBranchOnMerge bool     `json:"delete_branch_on_merge"`
+		MergeCommitTitle    string   `json:"merge_commit_title"`
+	}
+
 	user struct {
 		Login     string `json:"login"`
 		ID        int    `json:"id"`
@@ -30,6 +41,52 @@ type (
 		SiteAdmin bool   `json:"site_admin"`
 	}
 
+	// pullRequest is a pull request of the pulls api, including
+	// the assignees, milestone and auto merge state which are
+	// not part of scm.PullRequest.
+	pullRequest struct {
+		Number    int        `json:"number"`
+		State     string     `json:"state"`
+		Title     string     `json:"title"`
+		Body      string     `json:"body"`
+		Draft     bool       `json:"draft"`
+		DiffURL   string     `json:"diff_url"`
+		HTMLURL   string     `json:"html_url"`
+		User      user       `json:"user"`
+		Head      prRef      `json:"head"`
+		Base      prRef      `json:"base"`
+		MergedAt  *time.Time `json:"merged_at"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt time.Time  `json:"updated_at"`
+		Labels    []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"labels"`
+		Assignees []user `json:"assignees"`
+		Milestone *struct {
+			Title string `json:"title"`
+		} `json:"milestone"`
+		AutoMerge *struct {
+			MergeMethod string `json:"merge_method"`
+		} `json:"auto_merge"`
+	}
+
+	prRef struct {
+		Ref  string `json:"ref"`
+		Sha  string `json:"sha"`
+		Repo *struct {
+			FullName string `json:"full_name"`
+		} `json:"repo"`
+	}
+
+	// timelineEvent is an event of the issue timeline api.
+	timelineEvent struct {
+		Event     string    `json:"event"`
+		Actor     *user     `json:"actor"`
+		CommitID  string    `json:"commit_id"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
 	codeComment struct {
 		URL               string    `json:"url"`
 		ID                int       `json:"id"`
@@ -112,6 +169,34 @@ type (
 		Links                interface{}   `json:"_links"`
 	}
 
+	reviewThreadsResponse struct {
+		Data struct {
+			Repository struct {
+				PullRequest struct {
+					ReviewThreads struct {
+						Nodes    []reviewThread `json:"nodes"`
+						PageInfo struct {
+							EndCursor   string `json:"endCursor"`
+							HasNextPage bool   `json:"hasNextPage"`
+						} `json:"pageInfo"`
+					} `json:"reviewThreads"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+
+	reviewThread struct {
+		IsResolved bool `json:"isResolved"`
+		ResolvedBy *struct {
+			Login string `json:"login"`
+		} `json:"resolvedBy"`
+		Comments struct {
+			Nodes []struct {
+				DatabaseID int `json:"databaseId"`
+			} `json:"nodes"`
+		} `json:"comments"`
+	}
+
 	branchProtectionRulesResponse struct {
 		Data struct {
 			Repository struct {
@@ -18,6 +18,11 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"testing"
+	"time"
+
+	"github.com/harness/harness-migrate/internal/types"
+
+	"github.com/drone/go-scm/scm"
 )
 
 func TestExtractHunkInfo(t *testing.T) {
@@ -45,3 +50,16 @@ func TestExtractHunkInfo(t *testing.T) {
 		}
 	}
 }
+
+func TestLastThreadUpdate(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	root := &types.PRComment{Comment: scm.Comment{ID: 1, Updated: created}}
+	comments := []*types.PRComment{
+		root,
+		{Comment: scm.Comment{ID: 2, Updated: created.Add(time.Hour)}, ParentID: 1},
+		{Comment: scm.Comment{ID: 3, Updated: created.Add(2 * time.Hour)}},
+	}
+	if got, want := lastThreadUpdate(root, comments), created.Add(time.Hour); !got.Equal(want) {
+		t.Errorf("got = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/harness/harness-migrate/internal/gitexporter"
+)
+
+// uploadRegexp matches files uploaded to a project. The markdown
+// of GitLab references them relative to the project.
+var uploadRegexp = regexp.MustCompile(`(?:^|/)uploads/([0-9a-f]{32})/([^/?#]+)$`)
+
+// DownloadAttachment downloads files uploaded to merge requests and comments.
+func (e *Export) DownloadAttachment(ctx context.Context, repoSlug string, link string, limit int64) ([]byte, error) {
+	u, err := url.Parse(link)
+	if err != nil || u.Host != "" && u.Host != e.gitlab.BaseURL.Host {
+		return nil, nil
+	}
+
+	match := uploadRegexp.FindStringSubmatch(u.Path)
+	if match == nil {
+		return nil, nil
+	}
+
+	path := fmt.Sprintf("api/v4/projects/%s/uploads/%s/%s", encode(repoSlug), match[1], url.PathEscape(match[2]))
+	data, err := gitexporter.DownloadAttachment(ctx, e.gitlab, path, limit)
+	if err == nil || err == gitexporter.ErrAttachmentTooLarge {
+		return data, err
+	}
+
+	// the uploads api is not available before GitLab 17.4,
+	// fall back to the project url.
+	path = fmt.Sprintf("%s/uploads/%s/%s", repoSlug, match[1], url.PathEscape(match[2]))
+	return gitexporter.DownloadAttachment(ctx, e.gitlab, path, limit)
+}
@@ -49,6 +49,10 @@ type (
 		report     map[string]*report.Report
 
 		userMap map[string]user
+
+		// reviewers of the merge request being exported,
+		// shared by the reviews and requested reviewers.
+		reviewers map[string][]*reviewer
 	}
 )
 
@@ -87,6 +91,40 @@ func (e *Export) FindPR(
 	return convertPR(out), res, err
 }
 
+func (e *Export) ListMRApprovals(
+	ctx context.Context,
+	repoSlug string,
+	prNumber int,
+) (*approvals, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/approvals", encode(repoSlug), prNumber)
+	out := new(approvals)
+	res, err := e.do(ctx, "GET", path, nil, out)
+	return out, res, err
+}
+
+func (e *Export) ListMRReviewers(
+	ctx context.Context,
+	repoSlug string,
+	prNumber int,
+) ([]*reviewer, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/reviewers", encode(repoSlug), prNumber)
+	var out []*reviewer
+	res, err := e.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
+func (e *Export) ListMRStateEvents(
+	ctx context.Context,
+	repoSlug string,
+	prNumber int,
+	opts types.ListOptions,
+) ([]*stateEvent, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/resource_state_events?%s", encode(repoSlug), prNumber, encodeListOptions(opts))
+	var out []*stateEvent
+	res, err := e.do(ctx, "GET", path, nil, &out)
+	return out, res, err
+}
+
 func (e *Export) projectInfo(
 	ctx context.Context,
 	repoSlug string,
@@ -120,6 +158,17 @@ func (e *Export) ListRepoLabels(
 	return convertLabels(out), res, err
 }
 
+func (e *Export) ListGroupLabels(
+	ctx context.Context,
+	group string,
+	opts types.ListOptions,
+) ([]externalTypes.Label, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/groups/%s/labels?include_ancestor_groups=true&%s", encode(group), encodeListOptions(opts))
+	var out []*types.LabelResponse
+	res, err := e.do(ctx, "GET", path, nil, &out)
+	return convertLabels(out), res, err
+}
+
 func (e *Export) ListBranchRulesInternal(ctx context.Context,
 	repoSlug string,
 	opts types.ListOptions,
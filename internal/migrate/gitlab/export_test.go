@@ -13,3 +13,34 @@
 // limitations under the License.
 
 package gitlab
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestConvertResolution(t *testing.T) {
+	var d discussion
+	if err := json.Unmarshal([]byte(`{"notes": [
+		{"id": 1, "body": "nit", "author": {"username": "jane"}, "resolvable": true, "resolved": true,
+		 "resolved_by": {"username": "john"}, "resolved_at": "2024-01-02T00:00:00Z"},
+		{"id": 2, "body": "done", "author": {"username": "john"}, "resolvable": true, "resolved": true,
+		 "resolved_by": {"username": "john"}}
+	]}`), &d); err != nil {
+		t.Fatal(err)
+	}
+
+	comments := new(Export).convertPRComments(&d, 1)
+	if len(comments) != 2 {
+		t.Fatalf("want 2 comments, got %d", len(comments))
+	}
+	resolution := comments[0].Resolution
+	if resolution == nil || resolution.ResolvedBy.Login != "john" ||
+		!resolution.Resolved.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected resolution %+v", resolution)
+	}
+	if comments[1].Resolution != nil {
+		t.Errorf("want resolution on the first comment only")
+	}
+}
@@ -34,7 +34,9 @@ import (
 	"github.com/gotidy/ptr"
 )
 
-// Importer imports data from gitlab to Harness.
+// Importer imports data from gitlab to Harness. The gitlab
+// export does not include pipelines, so unlike the drone and
+// circle importers no pipelines are created, inline or remote.
 type Importer struct {
 	Harness      harness.Client
 	HarnessOrg   string
@@ -45,6 +47,10 @@ type Importer struct {
 	ScmToken string
 
 	Tracer tracer.Tracer
+
+	// Gitness imports into a Gitness instance, where
+	// organizations and projects are spaces.
+	Gitness bool
 }
 
 func (m *Importer) Import(ctx context.Context, data *types.Org) error {
@@ -64,6 +70,13 @@ func (m *Importer) Import(ctx context.Context, data *types.Org) error {
 		}
 	}
 
+	// gitness has no secret manager or connectors, repositories
+	// are cloned with the gitlab token directly.
+	if m.Gitness {
+		m.Tracer.Stop("create organization %s [done]", m.HarnessOrg)
+		return m.importProjects(ctx, data, org)
+	}
+
 	// wait for the harness secret manager to be created for the
 	// organization. It is created async and if we do not wait, it
 	// could result in failure to add secrets in subsequent steps.
@@ -94,7 +107,8 @@ func (m *Importer) Import(ctx context.Context, data *types.Org) error {
 	// find the github, gitlab or bitbucket connector or
 	// create if the connector does not already exist.
 	if _, err = m.Harness.FindConnectorOrg(org.ID, m.ScmType); err != nil {
-		conn := createGitlabConnector(org.ID, m.ScmType, m.ScmLogin, "org."+m.ScmType)
+		host := util.ParseRepoHost(util.FirstRepo(data), util.ProviderGitlab)
+		conn := util.CreateRepoConnector(org.ID, m.ScmType, host, m.ScmLogin, "org."+m.ScmType)
 		if err := m.Harness.CreateConnectorOrg(conn); err != nil {
 			return err
 		}
@@ -102,6 +116,12 @@ func (m *Importer) Import(ctx context.Context, data *types.Org) error {
 
 	m.Tracer.Stop("create connector %s [done]", m.ScmType)
 
+	return m.importProjects(ctx, data, org)
+}
+
+// importProjects creates a project and repository for each
+// gitlab project, and pushes the git repository.
+func (m *Importer) importProjects(ctx context.Context, data *types.Org, org *harness.Org) error {
 	// create tmp dir for cloning repos
 	tmpDir, err := os.MkdirTemp("", "harness-migrate-*")
 	if err != nil {
@@ -136,9 +156,11 @@ func (m *Importer) Import(ctx context.Context, data *types.Org) error {
 		// wait for the harness secret manager to be created for the
 		// project. It is created async and if we do not wait, it
 		// could result in failure to add secrets in subsequent steps.
-		if err := harness.WaitHarnessSecretManager(
-			m.Harness, m.HarnessOrg, project.Identifier); err != nil {
-			return err
+		if !m.Gitness {
+			if err := harness.WaitHarnessSecretManager(
+				m.Harness, m.HarnessOrg, project.Identifier); err != nil {
+				return err
+			}
 		}
 
 		m.Tracer.Stop("create project %s [done]", srcProject.Name)
@@ -248,36 +270,6 @@ func createSecretOrg(org, identifier, data string) *harness.Secret {
 	return createSecret(org, "", identifier, "", data)
 }
 
-// helper function to create a github connector
-func createGitlabConnector(org, id, username, token string) *harness.Connector {
-	return &harness.Connector{
-		Name:          id,
-		Identifier:    id,
-		Orgidentifier: org,
-		Type:          "Gitlab",
-		Spec: &harness.ConnectorGitlab{
-			Type: "Account",
-			URL:  "https://gitlab.com",
-			Authentication: &harness.Resource{
-				Type: "Http",
-				Spec: &harness.Resource{
-					Type: "UsernameToken",
-					Spec: &harness.ConnectorToken{
-						Username: username,
-						Tokenref: token,
-					},
-				},
-			},
-			Apiaccess: &harness.Resource{
-				Type: "Token",
-				Spec: &harness.ConnectorToken{
-					Tokenref: token,
-				},
-			},
-		},
-	}
-}
-
 // helper function return true if the error message
 // indicate the resource already exists.
 func isErrConflict(err error) bool {
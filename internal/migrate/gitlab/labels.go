@@ -97,3 +97,47 @@ func (e *Export) ListLabels(
 
 	return allLabels, nil
 }
+
+// ListSpaceLabels returns the labels of the group, including
+// the labels inherited from ancestor groups. A personal
+// namespace is not a group and has no group labels.
+func (e *Export) ListSpaceLabels(
+	ctx context.Context,
+	namespace string,
+	opts types.ListOptions,
+) (map[string]externalTypes.Label, error) {
+	e.tracer.Start(common.MsgStartExportSpaceLabels, namespace)
+	allLabels := make(map[string]externalTypes.Label)
+	defer func() {
+		e.tracer.Stop(common.MsgCompleteExportSpaceLabels, len(allLabels), namespace)
+	}()
+
+	if opts.Page == 0 {
+		opts.Page = 1
+	}
+	for {
+		labels, res, err := e.ListGroupLabels(ctx, namespace, types.ListOptions{
+			Page: opts.Page,
+			Size: 20,
+		})
+		if res != nil && res.Status == 404 {
+			break
+		}
+		if err != nil {
+			e.tracer.LogError(common.ErrListSpaceLabels, namespace, err)
+			return nil, fmt.Errorf(common.ErrListSpaceLabels, namespace, err)
+		}
+		if len(labels) == 0 {
+			break
+		}
+
+		// in Gitlab the combination of label's "name::value" is unique.
+		for _, label := range labels {
+			allLabels[label.Name+"::"+label.Value] = label
+		}
+
+		opts.Page += 1
+	}
+
+	return allLabels, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"strconv"
+
+	"github.com/harness/harness-migrate/internal/types"
+	externalTypes "github.com/harness/harness-migrate/types"
+
+	"github.com/drone/go-scm/scm"
+)
+
+// activityTypes maps the merge request states to activity types,
+// states not in the map are not exported.
+var activityTypes = map[string]externalTypes.ActivityType{
+	"closed":   externalTypes.ActivityClosed,
+	"reopened": externalTypes.ActivityReopened,
+	"merged":   externalTypes.ActivityMerged,
+}
+
+func convertPRActivities(from []*stateEvent) []*types.PRActivity {
+	var to []*types.PRActivity
+	for _, event := range from {
+		activityType, ok := activityTypes[event.State]
+		if !ok {
+			continue
+		}
+
+		activity := &types.PRActivity{
+			Type:    activityType,
+			Created: event.CreatedAt,
+		}
+		if event.User != nil {
+			activity.Actor = scm.User{
+				ID:     strconv.Itoa(event.User.ID),
+				Login:  event.User.Username,
+				Name:   event.User.Name,
+				Avatar: event.User.AvatarURL,
+			}
+		}
+		to = append(to, activity)
+	}
+	return to
+}
@@ -106,6 +106,14 @@ func (e *Export) addEmailToAuthorInComments(ctx context.Context, comments []*typ
 			return nil, fmt.Errorf("cannot find email for author %s: %w", commentCopy.Author.Login, err)
 		}
 		commentCopy.Author.Email = email
+		if comment.Resolution != nil {
+			resolution := *comment.Resolution
+			resolution.ResolvedBy.Email, err = e.FindEmailByUsername(ctx, resolution.ResolvedBy.Login)
+			if err != nil {
+				return nil, fmt.Errorf("cannot find email for resolver %s: %w", resolution.ResolvedBy.Login, err)
+			}
+			commentCopy.Resolution = &resolution
+		}
 		commentsCopy[i] = &commentCopy
 	}
 	return commentsCopy, nil
@@ -76,6 +76,11 @@ func (e *Export) convertPRComments(from *discussion, pr int) []*types.PRComment
 			}
 		}
 
+		// the discussion is resolved with its first note.
+		if parentID == 0 {
+			comment.Resolution = convertResolution(note)
+		}
+
 		parentID = note.ID
 		comments = append(comments, comment)
 	}
@@ -83,6 +88,27 @@ func (e *Export) convertPRComments(from *discussion, pr int) []*types.PRComment
 	return comments
 }
 
+// convertResolution returns the resolution of a resolved discussion
+// note. The update time is used when gitlab does not expose the
+// resolution time.
+func convertResolution(note codeComment) *types.Resolution {
+	if !note.Resolvable || !note.Resolved || note.ResolvedBy == nil {
+		return nil
+	}
+	resolved := note.UpdatedAt
+	if note.ResolvedAt != nil {
+		resolved = *note.ResolvedAt
+	}
+	return &types.Resolution{
+		Resolved: resolved,
+		ResolvedBy: scm.User{
+			Login:  note.ResolvedBy.Username,
+			Name:   note.ResolvedBy.Name,
+			Avatar: note.ResolvedBy.AvatarURL,
+		},
+	}
+}
+
 func extractHunkInfo(comment codeComment) string {
 	leftLineStart, rightLineStart := getOldNewLines(comment.Position.LineRange.Start.LineCode)
 	leftLineEnd, rightLineEnd := getOldNewLines(comment.Position.LineRange.End.LineCode)
@@ -17,15 +17,89 @@ package gitlab
 
 import (
 	"context"
+	"fmt"
 
-	"github.com/harness/harness-migrate/internal/codeerror"
+	"github.com/harness/harness-migrate/internal/common"
 	"github.com/harness/harness-migrate/internal/types"
 )
 
-func (e *Export) ListPullRequestReviews(ctx context.Context, repoSlug string, prNumber int, opts types.ListOptions) ([]*types.PRReview, error) {
-	return nil, &codeerror.OpNotSupportedError{Name: "ListPullRequestReviews"}
+// ListPullRequestReviews returns the approvals of the merge request
+// and the reviewers that requested changes.
+func (e *Export) ListPullRequestReviews(
+	ctx context.Context,
+	repoSlug string,
+	prNumber int,
+	opts types.ListOptions,
+) ([]*types.PRReview, error) {
+	e.tracer.Debug().Start(common.MsgStartExportPrReviewers, repoSlug, prNumber)
+	var reviews []*types.PRReview
+	defer func() {
+		e.tracer.Debug().Stop(common.MsgCompleteExportPrReviewers, len(reviews), repoSlug, prNumber)
+	}()
+
+	approved, _, err := e.ListMRApprovals(ctx, repoSlug, prNumber)
+	if err != nil {
+		e.tracer.LogError(common.ErrListReviewers, repoSlug, prNumber, err)
+		return nil, fmt.Errorf(common.ErrListReviewers, repoSlug, prNumber, err)
+	}
+
+	reviewers, err := e.listReviewers(ctx, repoSlug, prNumber)
+	if err != nil {
+		e.tracer.LogError(common.ErrListReviewers, repoSlug, prNumber, err)
+		return nil, fmt.Errorf(common.ErrListReviewers, repoSlug, prNumber, err)
+	}
+
+	reviews = convertPRReviews(approved, reviewers)
+	for _, review := range reviews {
+		email, err := e.FindEmailByUsername(ctx, review.Author.Login)
+		if err != nil {
+			return nil, fmt.Errorf("cannot find email for author %s: %w", review.Author.Login, err)
+		}
+		review.Author.Email = email
+	}
+	return reviews, nil
 }
 
+// ListRequestedReviewers returns the reviewers assigned to the merge request.
 func (e *Export) ListRequestedReviewers(ctx context.Context, repoSlug string, prNumber int) ([]*types.PRReviewer, error) {
-	return nil, &codeerror.OpNotSupportedError{Name: "ListRequestedReviewers"}
+	reviewers, err := e.listReviewers(ctx, repoSlug, prNumber)
+	if err != nil {
+		e.tracer.LogError(common.ErrListReviewers, repoSlug, prNumber, err)
+		return nil, fmt.Errorf(common.ErrListReviewers, repoSlug, prNumber, err)
+	}
+	delete(e.reviewers, reviewersKey(repoSlug, prNumber))
+
+	out := convertPRReviewers(reviewers)
+	for _, reviewer := range out {
+		email, err := e.FindEmailByUsername(ctx, reviewer.Login)
+		if err != nil {
+			return nil, fmt.Errorf("cannot find email for requested reviewer %s: %w", reviewer.Login, err)
+		}
+		reviewer.Email = email
+	}
+	return out, nil
+}
+
+// listReviewers returns the reviewers of the merge request. The
+// reviewers listed for the reviews are kept until the requested
+// reviewers of the same merge request are listed.
+func (e *Export) listReviewers(ctx context.Context, repoSlug string, prNumber int) ([]*reviewer, error) {
+	key := reviewersKey(repoSlug, prNumber)
+	if reviewers, ok := e.reviewers[key]; ok {
+		return reviewers, nil
+	}
+
+	reviewers, _, err := e.ListMRReviewers(ctx, repoSlug, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	if e.reviewers == nil {
+		e.reviewers = make(map[string][]*reviewer)
+	}
+	e.reviewers[key] = reviewers
+	return reviewers, nil
+}
+
+func reviewersKey(repoSlug string, prNumber int) string {
+	return fmt.Sprintf("%s#%d", repoSlug, prNumber)
 }
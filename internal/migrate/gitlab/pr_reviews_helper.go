@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/harness/harness-migrate/internal/types"
+	"github.com/harness/harness-migrate/internal/types/enum"
+
+	"github.com/drone/go-scm/scm"
+)
+
+// convertPRReviews converts the approvals and the reviewers that
+// requested changes to reviews. Gitlab does not expose the commit
+// a review was given on, the sha is left empty. Approvals without
+// a time use the time the approver was added as reviewer.
+func convertPRReviews(from *approvals, reviewers []*reviewer) []*types.PRReview {
+	var to []*types.PRReview
+	added := make(map[int]time.Time, len(reviewers))
+	for _, r := range reviewers {
+		added[r.User.ID] = r.CreatedAt
+	}
+
+	approvedBy := make(map[int]bool)
+	if from != nil {
+		for _, approval := range from.ApprovedBy {
+			created := added[approval.User.ID]
+			if approval.ApprovedAt != nil {
+				created = *approval.ApprovedAt
+			}
+			approvedBy[approval.User.ID] = true
+			to = append(to, convertPRReview(approval.User, enum.ReviewDecisionApproved, created))
+		}
+	}
+
+	for _, r := range reviewers {
+		if r.State != "requested_changes" || approvedBy[r.User.ID] {
+			continue
+		}
+		to = append(to, convertPRReview(r.User, enum.ReviewDecisionChangeReq, r.CreatedAt))
+	}
+	return to
+}
+
+func convertPRReview(from author, decision enum.ReviewDecision, created time.Time) *types.PRReview {
+	return &types.PRReview{
+		Review: scm.Review{
+			ID: from.ID,
+			Author: scm.User{
+				ID:     strconv.Itoa(from.ID),
+				Login:  from.Username,
+				Name:   from.Name,
+				Avatar: from.AvatarURL,
+			},
+			Created: created,
+			Updated: created,
+		},
+		State: decision,
+	}
+}
+
+func convertPRReviewers(from []*reviewer) []*types.PRReviewer {
+	var to []*types.PRReviewer
+	for _, r := range from {
+		to = append(to, &types.PRReviewer{
+			User: scm.User{
+				ID:     strconv.Itoa(r.User.ID),
+				Login:  r.User.Username,
+				Name:   r.User.Name,
+				Avatar: r.User.AvatarURL,
+			},
+		})
+	}
+	return to
+}
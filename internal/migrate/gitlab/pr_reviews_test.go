@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/harness/harness-migrate/internal/types/enum"
+)
+
+func TestConvertPRReviews(t *testing.T) {
+	var approved approvals
+	if err := json.Unmarshal([]byte(`{"approved_by":[
+		{"user":{"id":1,"username":"jane"}},
+		{"user":{"id":4,"username":"joe"},"approved_at":"2024-01-03T00:00:00Z"}
+	]}`), &approved); err != nil {
+		t.Fatal(err)
+	}
+	var reviewers []*reviewer
+	if err := json.Unmarshal([]byte(`[
+		{"user":{"id":1,"username":"jane"},"state":"approved","created_at":"2024-01-01T00:00:00Z"},
+		{"user":{"id":2,"username":"john"},"state":"requested_changes","created_at":"2024-01-02T00:00:00Z"},
+		{"user":{"id":3,"username":"jim"},"state":"unreviewed"}
+	]`), &reviewers); err != nil {
+		t.Fatal(err)
+	}
+
+	reviews := convertPRReviews(&approved, reviewers)
+	if len(reviews) != 3 {
+		t.Fatalf("want 3 reviews, got %d", len(reviews))
+	}
+	if reviews[0].Author.Login != "jane" || reviews[0].State != enum.ReviewDecisionApproved ||
+		reviews[0].Sha != "" || !reviews[0].Created.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected approval %+v", reviews[0])
+	}
+	if reviews[1].Author.Login != "joe" || !reviews[1].Created.Equal(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected approval %+v", reviews[1])
+	}
+	if reviews[2].Author.Login != "john" || reviews[2].State != enum.ReviewDecisionChangeReq || reviews[2].Sha != "" {
+		t.Errorf("unexpected review %+v", reviews[2])
+	}
+
+	if got := convertPRReviewers(reviewers); len(got) != 3 || got[2].Login != "jim" {
+		t.Errorf("unexpected reviewers %+v", got)
+	}
+}
@@ -113,5 +113,13 @@ func (e *Export) addEmailToPRAuthor(ctx context.Context, pr *types.PRResponse) (
 	}
 	pr.Author.Email = email
 
+	for i, assignee := range pr.Assignees {
+		email, err := e.FindEmailByUsername(ctx, assignee.Login)
+		if err != nil {
+			return types.PRResponse{}, fmt.Errorf("cannot find email for assignee %s: %w", assignee.Login, err)
+		}
+		pr.Assignees[i].Email = email
+	}
+
 	return *pr, nil
 }
@@ -16,6 +16,7 @@ package gitlab
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/drone/go-scm/scm"
 	"github.com/harness/harness-migrate/internal/types"
@@ -29,7 +30,26 @@ func convertPR(from mergeRequest) *types.PRResponse {
 		})
 	}
 
+	var assignees []scm.User
+	for _, assignee := range from.Assignees {
+		assignees = append(assignees, scm.User{
+			ID:     strconv.Itoa(assignee.ID),
+			Login:  assignee.Username,
+			Name:   assignee.Name,
+			Avatar: assignee.AvatarURL,
+		})
+	}
+
+	var milestone string
+	if from.Milestone != nil {
+		milestone = from.Milestone.Title
+	}
+
 	return &types.PRResponse{
+		Assignees: assignees,
+		Milestone: milestone,
+		Squash:    from.Squash,
+		AutoMerge: from.MergeWhenPipelineSucceeds,
 		PullRequest: scm.PullRequest{
 			Number: from.Number,
 			Title:  from.Title,
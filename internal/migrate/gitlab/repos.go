@@ -231,3 +231,25 @@ func (e *Export) GetLFSEnabledSettings(ctx context.Context, repoSlug string) (bo
 	e.tracer.Stop(common.MsgCompleteRepoLFSEnabled, repoSlug)
 	return res.LFSEnabled, nil
 }
+
+func (e *Export) GetRepoSettings(ctx context.Context, repoSlug string) (*types.RepoSettings, error) {
+	e.tracer.Start(common.MsgStartRepoSettings, repoSlug)
+	res, _, err := e.projectInfo(ctx, repoSlug)
+	if err != nil {
+		e.tracer.Stop(common.MsgCompleteRepoSettings, repoSlug)
+		return nil, err
+	}
+
+	settings := &types.RepoSettings{
+		Description: res.Description,
+		Topics:      res.Topics,
+	}
+	// merge methods and branch deletion are exported with the
+	// merge_rule branch rule, only the title format is kept here.
+	if res.MergeCommitTemplate != "" {
+		settings.Merge = &types.MergeSettings{TitleFormat: res.MergeCommitTemplate}
+	}
+
+	e.tracer.Stop(common.MsgCompleteRepoSettings, repoSlug)
+	return settings, nil
+}
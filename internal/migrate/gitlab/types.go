@@ -62,7 +62,10 @@ type (
 	}
 
 	repoInfo struct {
-		LFSEnabled bool `json:"lfs_enabled"`
+		LFSEnabled          bool     `json:"lfs_enabled"`
+		Description         string   `json:"description"`
+		Topics              []string `json:"topics"`
+		MergeCommitTemplate string   `json:"merge_commit_template"`
 	}
 
 	mergeRequest struct {
@@ -85,13 +88,42 @@ type (
 		Updated      time.Time `json:"updated_at"`
 		Closed       time.Time
 		Labels       []string `json:"labels"`
-		DiffRefs     struct {
+		Assignees    []author `json:"assignees"`
+		Milestone    *struct {
+			Title string `json:"title"`
+		} `json:"milestone"`
+		Squash                    bool `json:"squash"`
+		MergeWhenPipelineSucceeds bool `json:"merge_when_pipeline_succeeds"`
+		DiffRefs                  struct {
 			BaseSha  string `json:"base_sha"`
 			HeadSha  string `json:"head_sha"`
 			StartSha string `json:"start_sha"`
 		} `json:"diff_refs"`
 	}
 
+	// approvals is the approval state of a merge request.
+	approvals struct {
+		ApprovedBy []struct {
+			User       author     `json:"user"`
+			ApprovedAt *time.Time `json:"approved_at"`
+		} `json:"approved_by"`
+	}
+
+	// stateEvent is a state change of a merge request.
+	stateEvent struct {
+		ID        int       `json:"id"`
+		User      *author   `json:"user"`
+		State     string    `json:"state"` // closed, reopened, merged, locked
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	// reviewer is a reviewer assigned to a merge request.
+	reviewer struct {
+		User      author    `json:"user"`
+		State     string    `json:"state"` // unreviewed, reviewed, requested_changes, approved, unapproved
+		CreatedAt time.Time `json:"created_at"`
+	}
+
 	line struct {
 		LineCode string `json:"line_code"`
 		Type     string `json:"type"`
@@ -118,22 +150,23 @@ type (
 	}
 
 	codeComment struct {
-		ID           int       `json:"id"`
-		Type         string    `json:"type"`
-		Body         string    `json:"body"`
-		Author       author    `json:"author"`
-		CreatedAt    time.Time `json:"created_at"`
-		UpdatedAt    time.Time `json:"updated_at"`
-		System       bool      `json:"system"`
-		NoteableID   int       `json:"noteable_id"`
-		NoteableType string    `json:"noteable_type"`
-		ProjectID    int       `json:"project_id"`
-		CommitID     string    `json:"commit_id"`
-		Position     *position `json:"position"`
-		Resolved     bool      `json:"resolved"`
-		Resolvable   bool      `json:"resolvable"`
-		ResolvedBy   *author   `json:"resolved_by"`
-		Suggestions  []string  `json:"suggestions"`
+		ID           int        `json:"id"`
+		Type         string     `json:"type"`
+		Body         string     `json:"body"`
+		Author       author     `json:"author"`
+		CreatedAt    time.Time  `json:"created_at"`
+		UpdatedAt    time.Time  `json:"updated_at"`
+		System       bool       `json:"system"`
+		NoteableID   int        `json:"noteable_id"`
+		NoteableType string     `json:"noteable_type"`
+		ProjectID    int        `json:"project_id"`
+		CommitID     string     `json:"commit_id"`
+		Position     *position  `json:"position"`
+		Resolved     bool       `json:"resolved"`
+		Resolvable   bool       `json:"resolvable"`
+		ResolvedBy   *author    `json:"resolved_by"`
+		ResolvedAt   *time.Time `json:"resolved_at"`
+		Suggestions  []string   `json:"suggestions"`
 	}
 
 	discussion struct {
@@ -16,6 +16,7 @@ package migrate
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/drone/go-convert/convert/drone"
@@ -23,6 +24,8 @@ import (
 
 	"github.com/drone/go-scm/scm"
 	"github.com/harness/harness-migrate/internal/harness"
+	"github.com/harness/harness-migrate/internal/migrate/jenkins"
+	"github.com/harness/harness-migrate/internal/report"
 	"github.com/harness/harness-migrate/internal/slug"
 	"github.com/harness/harness-migrate/internal/tracer"
 	"github.com/harness/harness-migrate/internal/types"
@@ -48,12 +51,50 @@ type Importer struct {
 
 	Tracer tracer.Tracer
 
+	// Report collects placeholder secrets that must be
+	// updated after import, keyed by organization or
+	// project name.
+	Report map[string]*report.Report
+
 	Downgrade bool
+
+	// Remote stores converted pipelines in the source
+	// repository, under the .harness folder, instead of
+	// inline in harness.
+	Remote bool
+
+	// RemoteBranch is the branch where remote pipelines
+	// are committed. It defaults to the branch of the
+	// project.
+	RemoteBranch string
+
+	// Gitness imports into a Gitness instance, where
+	// organizations and projects are spaces, and pipelines
+	// are stored in the project repository with the same
+	// name as the source repository.
+	Gitness bool
+
+	// GitnessRepoSpace is the space of the gitness
+	// repositories pipelines are stored in. It defaults
+	// to the project space.
+	GitnessRepoSpace string
 }
 
 const dockerConnectorName = "docker"
 
+// secretPlaceholder is the value of secrets that could not
+// be read from the source system.
+const secretPlaceholder = "placeholder"
+
 func (m *Importer) Import(ctx context.Context, data *types.Org) error {
+	// gitness pipelines are stored in existing repositories,
+	// verify they exist before any space is created.
+	if m.Gitness {
+		if err := m.checkGitnessRepos(data); err != nil {
+			return err
+		}
+	}
+
 	m.Tracer.Start("create organization %s", m.HarnessOrg)
 
 	// find the harness organization
@@ -72,13 +113,16 @@ func (m *Importer) Import(ctx context.Context, data *types.Org) error {
 	// wait for the harness secret manager to be created for the
 	// organization. It is created async and if we do not wait, it
 	// could result in failure to add secrets in subsequent steps.
-	if err := harness.WaitHarnessSecretManagerOrg(m.Harness, m.HarnessOrg); err != nil {
-		return err
+	if !m.Gitness {
+		if err := harness.WaitHarnessSecretManagerOrg(m.Harness, m.HarnessOrg); err != nil {
+			return err
+		}
 	}
 	m.Tracer.Stop("create organization %s [done]", m.HarnessOrg)
 
-	// create the scm secret if it does not already exist.
-	if m.RepoConn == "" {
+	// create the scm secret if it does not already exist. gitness
+	// pipelines are stored in gitness, and need no connectors.
+	if m.RepoConn == "" && !m.Gitness {
 		m.Tracer.Start("create provider secret %s", m.ScmType)
 		// create if the secret does not already exist.
 		if _, err = m.Harness.FindSecretOrg(org.ID, m.ScmType); err != nil {
@@ -98,7 +142,7 @@ func (m *Importer) Import(ctx context.Context, data *types.Org) error {
 	var orgSecrets []string
 	for _, secret := range data.Secrets {
 		if _, err = m.Harness.FindSecretOrg(org.ID, secret.Name); err != nil {
-			s := util.CreateSecretOrg(org.ID, secret.Name, secret.Value)
+			s := util.CreateSecretOrg(org.ID, secret.Name, m.secretValue(data.Name, secret))
 			// save the secret to the organization
 			if err := m.Harness.CreateSecretOrg(s); err != nil {
 				return err
@@ -109,19 +153,29 @@ func (m *Importer) Import(ctx context.Context, data *types.Org) error {
 
 	m.Tracer.Stop("create organisation secrets [done]")
 
+	if len(data.Variables) > 0 && m.Gitness {
+		m.Tracer.Log("Gitness does not support variables, skipping %d organisation variables.", len(data.Variables))
+	} else if len(data.Variables) > 0 {
+		m.Tracer.Start("create organisation variables")
+		for _, variable := range data.Variables {
+			v := util.CreateVariableOrg(org.ID, slug.Create(variable.Name), variable.Name, variable.Value)
+			if err := m.Harness.CreateVariable(v); err != nil && !util.IsErrConflict(err) {
+				return err
+			}
+		}
+		m.Tracer.Stop("create organisation variables [done]")
+	}
+
 	repoConn := m.RepoConn
-	if repoConn == "" {
+	if repoConn == "" && !m.Gitness {
 		m.Tracer.Start("check for connector %s", m.ScmType)
 		foundConnector, err := m.Harness.FindConnectorOrg(org.ID, m.ScmType)
 		if err != nil || foundConnector == nil {
 			m.Tracer.Start("create connector %s", m.ScmType)
-			var conn *harness.Connector
-			switch m.ScmType {
-			case "gitlab":
-				conn = util.CreateGitlabConnector(org.ID, m.ScmType, m.ScmLogin, "org."+m.ScmType)
-			default:
-				conn = util.CreateGithubConnector(org.ID, m.ScmType, m.ScmLogin, "org."+m.ScmType)
-			}
+			// select the connector type from the host of the
+			// source repositories, e.g. bitbucket server or azure.
+			host := util.ParseRepoHost(util.FirstRepo(data), m.ScmType)
+			conn := util.CreateRepoConnector(org.ID, m.ScmType, host, m.ScmLogin, "org."+m.ScmType)
 			if err := m.Harness.CreateConnectorOrg(conn); err != nil {
 				return err
 			}
@@ -134,7 +188,7 @@ func (m *Importer) Import(ctx context.Context, data *types.Org) error {
 	}
 
 	dockerConn := m.DockerConn
-	if dockerConn == "" {
+	if dockerConn == "" && !m.Gitness {
 		m.Tracer.Start("check for docker connector %s", dockerConnectorName)
 		existingConnector, err := m.Harness.FindConnectorOrg(org.ID, dockerConnectorName)
 		if err != nil || existingConnector == nil {
@@ -184,16 +238,18 @@ func (m *Importer) Import(ctx context.Context, data *types.Org) error {
 		// wait for the harness secret manager to be created for the
 		// project. It is created async and if we do not wait, it
 		// could result in failure to add secrets in subsequent steps.
-		if err := harness.WaitHarnessSecretManager(
-			m.Harness, m.HarnessOrg, projectSlug); err != nil {
-			return err
+		if !m.Gitness {
+			if err := harness.WaitHarnessSecretManager(
+				m.Harness, m.HarnessOrg, projectSlug); err != nil {
+				return err
+			}
 		}
 
 		// for each environment variable
 		for _, srcEnv := range srcProject.Secrets {
 			// convert the environment variable to an inline
 			// secret, stored in the harness secret manager.
-			secret := util.CreateSecret(org.ID, projectSlug, slug.Create(srcEnv.Name), srcEnv.Desc, srcEnv.Value)
+			secret := util.CreateSecret(org.ID, projectSlug, slug.Create(srcEnv.Name), srcEnv.Desc, m.secretValue(srcProject.Name, srcEnv))
 			// save the secret to harness.
 			if err := m.Harness.CreateSecret(secret); err != nil {
 				// if the error indicates the secret already
@@ -206,42 +262,59 @@ func (m *Importer) Import(ctx context.Context, data *types.Org) error {
 			}
 		}
 
-		converter := drone.New(
-			drone.WithDockerhub(dockerConn),
-			drone.WithKubernetes(m.KubeName, m.KubeConn),
-			drone.WithOrgSecrets(orgSecrets...),
-		)
+		if len(srcProject.Variables) > 0 && m.Gitness {
+			m.Tracer.Log("Gitness does not support variables, skipping %d variables of %s.", len(srcProject.Variables), srcProject.Name)
+			srcProject.Variables = nil
+		}
+		for _, srcVar := range srcProject.Variables {
+			v := util.CreateVariable(org.ID, projectSlug, slug.Create(srcVar.Name), srcVar.Name, srcVar.Value)
+			if err := m.Harness.CreateVariable(v); err != nil && !util.IsErrConflict(err) {
+				return err
+			}
+		}
 
-		convertedYaml, err := converter.ConvertBytes(srcProject.Yaml)
-		if err != nil {
-			return err
+		// projects with pipelines were converted to the harness
+		// yaml format during export, and each pipeline is
+		// created separately.
+		if len(srcProject.Pipelines) > 0 {
+			for _, srcPipeline := range srcProject.Pipelines {
+				convertedYaml, err := m.downgrade([]byte(srcPipeline.Yaml), srcPipeline.Name, project.Name, repoConn, dockerConn)
+				if err != nil {
+					return err
+				}
+				if err := m.createPipeline(org.ID, projectSlug, srcProject, srcPipeline.Name, repoConn, convertedYaml); err != nil {
+					return err
+				}
+			}
+			m.Tracer.Stop("create project %s [done]", srcProject.Name)
+			continue
 		}
-		// downgrade to v0 if needed
-		if m.Downgrade {
-			d := downgrader.New(
-				downgrader.WithCodebase(project.Name, repoConn),
-				downgrader.WithDockerhub(dockerConn),
-				downgrader.WithKubernetes(m.KubeName, m.KubeConn),
-				downgrader.WithName(project.Name),
-				downgrader.WithOrganization(m.HarnessOrg),
-				downgrader.WithProject(slug.Create(project.Name)),
+
+		convertedYaml := srcProject.Yaml
+		// jenkins projects are converted to the harness yaml
+		// format during export.
+		if srcProject.Type != jenkins.ProjectType {
+			converter := drone.New(
+				drone.WithDockerhub(dockerConn),
+				drone.WithKubernetes(m.KubeName, m.KubeConn),
+				drone.WithOrgSecrets(orgSecrets...),
 			)
-			convertedYaml, err = d.Downgrade(convertedYaml)
+
+			convertedYaml, err = converter.ConvertBytes(srcProject.Yaml)
 			if err != nil {
-				return nil
+				return err
 			}
 		}
+		// downgrade to v0 if needed
+		convertedYaml, err = m.downgrade(convertedYaml, project.Name, project.Name, repoConn, dockerConn)
+		if err != nil {
+			return err
+		}
 		srcProject.Yaml = convertedYaml
 
 		//create the harness pipeline with an inline yaml
-		err = m.Harness.CreatePipeline(org.ID, projectSlug, srcProject.Yaml)
-		if err != nil {
-			// if the error indicates the pipeline already
-			// exists we can continue with the import, else
-			// we should return the error and exit the import.
-			if !util.IsErrConflict(err) {
-				return err
-			}
+		if err := m.createPipeline(org.ID, projectSlug, srcProject, srcProject.Name, repoConn, srcProject.Yaml); err != nil {
+			return err
 		}
 
 		m.Tracer.Stop("create project %s [done]", srcProject.Name)
@@ -250,6 +323,120 @@ func (m *Importer) Import(ctx context.Context, data *types.Org) error {
 	return nil
 }
 
+// downgrade converts the pipeline yaml to the v0 format if
+// downgrading is enabled.
+func (m *Importer) downgrade(yaml []byte, name, project, repoConn, dockerConn string) ([]byte, error) {
+	if !m.Downgrade {
+		return yaml, nil
+	}
+	d := downgrader.New(
+		downgrader.WithCodebase(project, repoConn),
+		downgrader.WithDockerhub(dockerConn),
+		downgrader.WithKubernetes(m.KubeName, m.KubeConn),
+		downgrader.WithName(name),
+		downgrader.WithOrganization(m.HarnessOrg),
+		downgrader.WithProject(slug.Create(project)),
+	)
+	return d.Downgrade(yaml)
+}
+
+// createPipeline creates the harness pipeline with an inline
+// yaml, or a remote yaml committed to the project repository.
+func (m *Importer) createPipeline(org, project string, src *types.Project, name, repoConn string, yaml []byte) error {
+	var err error
+	if m.Remote || m.Gitness {
+		var store *harness.GitStore
+		store, err = m.gitStore(org, project, src, name, repoConn)
+		if err != nil {
+			return err
+		}
+		err = m.Harness.CreateRemotePipeline(org, project, yaml, store)
+	} else {
+		err = m.Harness.CreatePipeline(org, project, yaml)
+	}
+	if err != nil {
+		// if the error indicates the pipeline already
+		// exists we can continue with the import, else
+		// we should return the error and exit the import.
+		if !util.IsErrConflict(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// gitStore returns the location of the remote pipeline yaml
+// in the project repository. The repository name is relative
+// to the account level repository connector.
+func (m *Importer) gitStore(org, project string, src *types.Project, name, repoConn string) (*harness.GitStore, error) {
+	repo, err := util.ParseRepoURL(src.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("project %s: %w", src.Name, err)
+	}
+	branch := m.RemoteBranch
+	if branch == "" {
+		branch = src.Branch
+	}
+	if branch == "" {
+		return nil, fmt.Errorf("project %s: cannot determine the branch for remote pipelines", src.Name)
+	}
+	// the repository path is relative to the connector url,
+	// which includes the organization and project for azure.
+	repoName := repo.Repo
+	if m.Gitness {
+		// gitness pipelines are stored in the repository of
+		// the project space, referenced by its path.
+		space := m.GitnessRepoSpace
+		if space == "" {
+			space = util.JoinPaths(org, project)
+		}
+		repoName = util.JoinPaths(space, repo.Name)
+	}
+	return &harness.GitStore{
+		ConnectorRef: repoConn,
+		RepoName:     repoName,
+		Branch:       branch,
+		FilePath:     ".harness/" + slug.Create(name) + ".yaml",
+		CommitMsg:    "Add pipeline " + name,
+	}, nil
+}
+
+// checkGitnessRepos returns an error if the gitness repository
+// of any project to import does not exist.
+func (m *Importer) checkGitnessRepos(data *types.Org) error {
+	for _, srcProject := range data.Projects {
+		if len(m.RepositoryList) > 0 && !m.repositoryInList(srcProject.Name) {
+			continue
+		}
+		store, err := m.gitStore(m.HarnessOrg, slug.Create(srcProject.Name), srcProject, srcProject.Name, "")
+		if err != nil {
+			return err
+		}
+		if _, err := m.Harness.GetRepository(store.RepoName); err != nil {
+			return fmt.Errorf("project %s: gitness repository %s not found, migrate the repository first or set its space with --gitness-repo-space: %w",
+				srcProject.Name, store.RepoName, err)
+		}
+	}
+	return nil
+}
+
+// secretValue returns the secret value, or a placeholder
+// value if the secret could not be exported. Placeholder
+// secrets are listed in the report under the owner name.
+func (m *Importer) secretValue(owner string, secret *types.Secret) string {
+	if !secret.Placeholder {
+		return secret.Value
+	}
+	m.Tracer.Log("Secret %s in %s created with a placeholder value.", secret.Name, owner)
+	if m.Report != nil {
+		if _, ok := m.Report[owner]; !ok {
+			m.Report[owner] = report.Init(owner)
+		}
+		m.Report[owner].ReportDetail(report.ReportTypeSecrets, secret.Name, "created with a placeholder value, update it in harness")
+	}
+	return secretPlaceholder
+}
+
 func (m *Importer) repositoryInList(repoName string) bool {
 	lowerRepoName := strings.ToLower(repoName)
 	for _, name := range m.RepositoryList {
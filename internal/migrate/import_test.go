@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"testing"
+
+	"github.com/harness/harness-migrate/internal/harness"
+	"github.com/harness/harness-migrate/internal/types"
+
+	"github.com/h2non/gock"
+)
+
+func TestCheckGitnessRepos(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitness.example.com").
+		Get("/api/v1/repos/.+").
+		Reply(200).
+		JSON(map[string]string{"identifier": "hello-world"})
+
+	importer := &Importer{
+		Harness:        harness.NewGitness("dummy0d0ac576df34be6a882", "https://gitness.example.com"),
+		HarnessOrg:     "acme",
+		Gitness:        true,
+		RepositoryList: []string{"hello-world"},
+	}
+	org := &types.Org{
+		Projects: []*types.Project{
+			{Name: "hello-world", Repo: "https://github.com/octocat/hello-world.git", Branch: "main"},
+			{Name: "excluded", Repo: "https://github.com/octocat/excluded.git", Branch: "main"},
+		},
+	}
+	if err := importer.checkGitnessRepos(org); err != nil {
+		t.Errorf("Want excluded repositories not to be checked, got %s", err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Want repository of the listed project checked")
+	}
+
+	importer.RepositoryList = nil
+	if err := importer.checkGitnessRepos(org); err == nil {
+		t.Errorf("Want error for a missing repository")
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package client provides a Jenkins http client.
+package client
+
+// Client is used to communicate with the Jenkins server.
+type Client interface {
+	// ListJobs returns the job list of the folder at the
+	// given path. The top-level job list is returned when
+	// the path is empty.
+	ListJobs(path string) ([]*Job, error)
+
+	// FindConfig returns the config.xml of the job at the
+	// given path.
+	FindConfig(path string) ([]byte, error)
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// defaultTimeout bounds each request so an unresponsive
+// server cannot stall the export.
+const defaultTimeout = time.Minute
+
+type client struct {
+	client   *http.Client
+	tracing  bool
+	address  string
+	username string
+	password string
+}
+
+// New returns a new Client.
+func New(address string, opts ...Option) Client {
+	client_ := &client{
+		client:  &http.Client{Timeout: defaultTimeout},
+		address: strings.TrimSuffix(address, "/"),
+	}
+	// set optional parameters.
+	for _, opt := range opts {
+		opt(client_)
+	}
+	return client_
+}
+
+// ListJobs returns the job list of the folder at the
+// given path.
+func (c *client) ListJobs(path string) ([]*Job, error) {
+	out := new(JobList)
+	uri := fmt.Sprintf("%s%s/api/json?tree=jobs[name,url]", c.address, jobPath(path))
+	err := c.get(uri, &out)
+	return out.Jobs, err
+}
+
+// FindConfig returns the config.xml of the job at the
+// given path.
+func (c *client) FindConfig(path string) ([]byte, error) {
+	uri := fmt.Sprintf("%s%s/config.xml", c.address, jobPath(path))
+	body, err := c.open(uri, "GET")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// helper function converts a slash separated job path
+// (e.g. folder/job) to the jenkins url path
+// (e.g. /job/folder/job/job).
+func jobPath(path string) string {
+	var sb strings.Builder
+	for _, name := range strings.Split(path, "/") {
+		if name == "" {
+			continue
+		}
+		sb.WriteString("/job/")
+		sb.WriteString(url.PathEscape(name))
+	}
+	return sb.String()
+}
+
+//
+// http request helper functions
+//
+
+// helper function for making an http GET request.
+func (c *client) get(rawurl string, out interface{}) error {
+	body, err := c.open(rawurl, "GET")
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	return json.NewDecoder(body).Decode(out)
+}
+
+// helper function to open an http request
+func (c *client) open(rawurl, method string) (io.ReadCloser, error) {
+	uri, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(method, uri.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	if c.tracing {
+		dump, _ := httputil.DumpRequest(req, true)
+		os.Stdout.Write(dump)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if c.tracing {
+		dump, _ := httputil.DumpResponse(resp, true)
+		os.Stdout.Write(dump)
+	}
+	if resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		out, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("client error %d: %s", resp.StatusCode, string(out))
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h2non/gock"
+)
+
+func TestListJobs(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://jenkins.example.com").
+		Get("/job/team/job/backend/api/json").
+		MatchParam("tree", "jobs\\[name,url\\]").
+		MatchHeader("Authorization", "Basic YWRtaW46dG9rZW4=").
+		Reply(200).
+		File("testdata/job_list.json")
+
+	client := New("https://jenkins.example.com/", WithCredentials("admin", "token"))
+	got, err := client.ListJobs("team/backend")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*Job{}
+	raw, _ := os.ReadFile("testdata/job_list.json.golden")
+	json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestFindConfig(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://jenkins.example.com").
+		Get("/job/team/job/hello/config.xml").
+		Reply(200).
+		File("testdata/config.xml")
+
+	client := New("https://jenkins.example.com")
+	got, err := client.FindConfig("team/hello")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want, _ := os.ReadFile("testdata/config.xml")
+	if diff := cmp.Diff(string(got), string(want)); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestJobPath(t *testing.T) {
+	tests := map[string]string{
+		"":                  "",
+		"hello":             "/job/hello",
+		"team/hello":        "/job/team/job/hello",
+		"/team/feature%2Fx": "/job/team/job/feature%252Fx",
+	}
+	for path, want := range tests {
+		if got := jobPath(path); got != want {
+			t.Errorf("want job path %q for %q, got %q", want, path, got)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+// Option configures a Jenkins client option.
+type Option func(*client)
+
+// WithCredentials returns an option to set the basic
+// auth username and password or api token.
+func WithCredentials(username, password string) Option {
+	return func(p *client) {
+		p.username = username
+		p.password = password
+	}
+}
+
+// WithTracing returns an option to enable tracing.
+func WithTracing(tracing bool) Option {
+	return func(p *client) {
+		p.tracing = tracing
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+// Jenkins classes of jobs that contain other jobs.
+const (
+	ClassFolder             = "com.cloudbees.hudson.plugins.folder.Folder"
+	ClassOrganizationFolder = "jenkins.branch.OrganizationFolder"
+	ClassMultiBranchProject = "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"
+)
+
+type (
+	// Job defines a job.
+	Job struct {
+		Class string `json:"_class"`
+		Name  string `json:"name"`
+		URL   string `json:"url"`
+	}
+
+	// JobList defines a job list.
+	JobList struct {
+		Jobs []*Job `json:"jobs"`
+	}
+)
+
+// IsFolder returns true if the job contains other jobs,
+// such as folders, organization folders and multibranch
+// projects.
+func (j *Job) IsFolder() bool {
+	switch j.Class {
+	case ClassFolder, ClassOrganizationFolder, ClassMultiBranchProject:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkins
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"strings"
+
+	"github.com/drone/go-convert/convert/jenkinsxml"
+
+	"github.com/harness/harness-migrate/internal/migrate/jenkins/client"
+	"github.com/harness/harness-migrate/internal/tracer"
+	"github.com/harness/harness-migrate/internal/types"
+)
+
+// ProjectType identifies projects exported from Jenkins.
+// The project yaml is already converted to the harness
+// yaml format.
+const ProjectType = "jenkins"
+
+// Exporter exports data from Jenkins.
+type Exporter struct {
+	Jenkins client.Client
+
+	// Folder is the optional folder path used as the
+	// root of the export. The whole instance is exported
+	// when empty.
+	Folder string
+
+	// Org is the name of the exported organization.
+	Org string
+
+	DockerConn string
+	KubeName   string
+	KubeConn   string
+
+	Tracer tracer.Tracer
+}
+
+// Export exports Jenkins data.
+func (m *Exporter) Export(ctx context.Context) (*types.Org, error) {
+	dstOrg := &types.Org{
+		Name: m.Org,
+	}
+	if dstOrg.Name == "" {
+		dstOrg.Name = "jenkins"
+	}
+
+	m.Tracer.Start("export organization")
+	if err := m.walk(ctx, strings.Trim(m.Folder, "/"), dstOrg); err != nil {
+		m.Tracer.Stop("Failed to export organization: %s", err.Error())
+		return nil, err
+	}
+	m.Tracer.Stop("export organization %s [done]", dstOrg.Name)
+
+	return dstOrg, nil
+}
+
+// walk recursively exports the jobs of the folder at the
+// given path. Folders and multibranch projects are walked,
+// all other jobs are converted to projects.
+func (m *Exporter) walk(ctx context.Context, path string, dstOrg *types.Org) error {
+	jobs, err := m.Jenkins.ListJobs(path)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		jobPath := job.Name
+		if path != "" {
+			jobPath = path + "/" + job.Name
+		}
+
+		if job.IsFolder() {
+			if err := m.walk(ctx, jobPath, dstOrg); err != nil {
+				return err
+			}
+			continue
+		}
+
+		m.Tracer.Start("export project %s", jobPath)
+
+		config, err := m.Jenkins.FindConfig(jobPath)
+		if err != nil {
+			m.Tracer.Log("Skipping job %s: failed to download config.xml: %s", jobPath, err.Error())
+			continue
+		}
+
+		converter := jenkinsxml.New(
+			jenkinsxml.WithDockerhub(m.DockerConn),
+			jenkinsxml.WithKubernetes(m.KubeName, m.KubeConn),
+		)
+		converted, err := converter.ConvertBytes(config)
+		if err != nil {
+			m.Tracer.Log("Skipping job %s: failed to convert config.xml: %s", jobPath, err.Error())
+			continue
+		}
+
+		repo, branch := parseSCM(config)
+
+		// convert the jenkins job to a common format.
+		dstProject := &types.Project{
+			Name:   strings.ReplaceAll(jobPath, "/", "-"),
+			Desc:   jobPath,
+			Type:   ProjectType,
+			Repo:   repo,
+			Branch: branch,
+			Yaml:   converted,
+		}
+
+		// append projects to the org
+		dstOrg.Projects = append(dstOrg.Projects, dstProject)
+
+		m.Tracer.Stop("export project %s [done]", jobPath)
+	}
+	return nil
+}
+
+// parseSCM returns the git repository url and branch
+// configured in the job config.xml, if any.
+func parseSCM(config []byte) (repo, branch string) {
+	// encoding/xml does not support XML 1.1, which jenkins
+	// uses, so the declaration is rewritten the same way the
+	// go-convert jenkinsxml parser does.
+	config = bytes.Replace(config, []byte("<?xml version='1.1"), []byte("<?xml version='1.0"), 1)
+	dec := xml.NewDecoder(bytes.NewReader(config))
+
+	var stack []string
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			if len(stack) < 2 {
+				continue
+			}
+			value := strings.TrimSpace(string(t))
+			name, parent := stack[len(stack)-1], stack[len(stack)-2]
+			switch {
+			case repo == "" && name == "url" && parent == "hudson.plugins.git.UserRemoteConfig":
+				repo = value
+			case repo == "" && name == "remote" && parent == "source":
+				repo = value
+			case branch == "" && name == "name" && parent == "hudson.plugins.git.BranchSpec":
+				branch = value
+				branch = strings.TrimPrefix(branch, "*/")
+				branch = strings.TrimPrefix(branch, "refs/heads/")
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jenkins
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/harness/harness-migrate/internal/migrate/jenkins/client"
+	"github.com/harness/harness-migrate/internal/tracer"
+)
+
+type mockClient struct {
+	jobs   map[string][]*client.Job
+	config []byte
+}
+
+func (c *mockClient) ListJobs(path string) ([]*client.Job, error) {
+	return c.jobs[path], nil
+}
+
+func (c *mockClient) FindConfig(path string) ([]byte, error) {
+	if path == "team/broken" {
+		return nil, errors.New("not found")
+	}
+	return c.config, nil
+}
+
+func TestExport(t *testing.T) {
+	config, err := os.ReadFile("client/testdata/config.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exporter := &Exporter{
+		Jenkins: &mockClient{
+			config: config,
+			jobs: map[string][]*client.Job{
+				"": {
+					{Name: "team", Class: client.ClassFolder},
+					{Name: "hello", Class: "hudson.model.FreeStyleProject"},
+				},
+				"team": {
+					{Name: "service", Class: client.ClassMultiBranchProject},
+					{Name: "broken", Class: "hudson.model.FreeStyleProject"},
+				},
+				"team/service": {
+					{Name: "main", Class: "org.jenkinsci.plugins.workflow.job.WorkflowJob"},
+				},
+			},
+		},
+		Tracer: tracer.Default(),
+	}
+
+	org, err := exporter.Export(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := org.Name, "jenkins"; got != want {
+		t.Errorf("want org name %q, got %q", want, got)
+	}
+	if got, want := len(org.Projects), 2; got != want {
+		t.Fatalf("want %d projects, got %d", want, got)
+	}
+
+	project := org.Projects[0]
+	if got, want := project.Name, "team-service-main"; got != want {
+		t.Errorf("want project name %q, got %q", want, got)
+	}
+	if got, want := project.Type, ProjectType; got != want {
+		t.Errorf("want project type %q, got %q", want, got)
+	}
+	if got, want := project.Repo, "https://github.com/octocat/hello-world.git"; got != want {
+		t.Errorf("want project repo %q, got %q", want, got)
+	}
+	if got, want := project.Branch, "main"; got != want {
+		t.Errorf("want project branch %q, got %q", want, got)
+	}
+	if len(project.Yaml) == 0 {
+		t.Errorf("want converted project yaml")
+	}
+	if got, want := org.Projects[1].Name, "hello"; got != want {
+		t.Errorf("want project name %q, got %q", want, got)
+	}
+}
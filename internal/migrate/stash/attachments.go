@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stash
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/harness/harness-migrate/internal/gitexporter"
+
+	"github.com/drone/go-scm/scm"
+)
+
+// DownloadAttachment downloads files attached to pull requests and comments.
+// Bitbucket Server references them as attachment:<repository id>/<attachment id>%2F<file name>.
+func (e *Export) DownloadAttachment(ctx context.Context, repoSlug string, link string, limit int64) ([]byte, error) {
+	id, ok := strings.CutPrefix(link, "attachment:")
+	if !ok {
+		return nil, nil
+	}
+	// the attachment belongs to the repository of the pull request.
+	if i := strings.Index(id, "/"); i >= 0 {
+		id = id[i+1:]
+	}
+	if unescaped, err := url.PathUnescape(id); err == nil {
+		id = unescaped
+	}
+	id, _, _ = strings.Cut(id, "/")
+
+	namespace, name := scm.Split(repoSlug)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/attachments/%s", namespace, name, url.PathEscape(id))
+	return gitexporter.DownloadAttachment(ctx, e.stash, path, limit)
+}
@@ -26,6 +26,10 @@ type (
 		tracer     tracer.Tracer
 		fileLogger gitexporter.Logger
 		report     map[string]*report.Report
+
+		// participants of the pull request being exported,
+		// shared by the reviews and requested reviewers.
+		participants map[string]*pullRequestParticipants
 	}
 )
 
@@ -67,6 +71,36 @@ func (e *Export) ListPRComments(
 	return convertPullRequestCommentsList(out.Values), res, err
 }
 
+func (e *Export) ListPRActivities(
+	ctx context.Context,
+	repoSlug string,
+	prNumber int,
+	opts types.ListOptions,
+) ([]*types.PRActivity, *scm.Response, error) {
+	namespace, name := scm.Split(repoSlug)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/activities?%s",
+		namespace, name, prNumber, encodeListOptions(opts))
+	out := new(activities)
+	res, err := e.do(ctx, "GET", path, out)
+	if err == nil && !out.pagination.LastPage {
+		res.Page.First = 1
+		res.Page.Next = opts.Page + 1
+	}
+	return convertPRActivities(out.Values), res, err
+}
+
+func (e *Export) getPRParticipants(
+	ctx context.Context,
+	repoSlug string,
+	prNumber int,
+) (*pullRequestParticipants, error) {
+	namespace, name := scm.Split(repoSlug)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d", namespace, name, prNumber)
+	out := new(pullRequestParticipants)
+	_, err := e.do(ctx, "GET", path, out)
+	return out, err
+}
+
 func (e *Export) ListBranchRulesInternal(
 	ctx context.Context,
 	repoSlug string,
@@ -114,6 +148,38 @@ func (e *Export) checkLFSEnabled(
 	return false, fmt.Errorf("failed to check Git LFS allowed for %q: %w", repoSlug, err)
 }
 
+func (e *Export) getRepoSettings(
+	ctx context.Context,
+	repoSlug string,
+) (*types.RepoSettings, error) {
+	namespace, name := scm.Split(repoSlug)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s", namespace, name)
+	repo := new(repository)
+	if _, err := e.do(ctx, "GET", path, repo); err != nil {
+		return nil, err
+	}
+
+	path = fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/settings/pull-requests", namespace, name)
+	prSettings := new(pullRequestSettings)
+	if _, err := e.do(ctx, "GET", path, prSettings); err != nil {
+		return nil, err
+	}
+
+	// the auto-delete setting requires admin access and is
+	// treated as disabled when it cannot be read.
+	path = fmt.Sprintf("rest/pull-request-cleanup/latest/projects/%s/repos/%s", namespace, name)
+	cleanup := new(pullRequestCleanup)
+	e.do(ctx, "GET", path, cleanup)
+
+	return &types.RepoSettings{
+		Description: repo.Description,
+		Merge: &types.MergeSettings{
+			StrategiesAllowed: convertMergeStrategies(prSettings.MergeConfig.Strategies),
+			DeleteBranch:      cleanup.DeleteSourceBranch,
+		},
+	}, nil
+}
+
 func (e *Export) do(ctx context.Context, method, path string, out any) (*scm.Response, error) {
 	req := &scm.Request{
 		Method: method,
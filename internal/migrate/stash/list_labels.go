@@ -28,3 +28,13 @@ func (e *Export) ListLabels(
 ) (map[string]externalTypes.Label, error) {
 	return nil, nil
 }
+
+// ListSpaceLabels returns the labels of the project.
+// Bitbucket Server has no labels.
+func (e *Export) ListSpaceLabels(
+	ctx context.Context,
+	namespace string,
+	opts types.ListOptions,
+) (map[string]externalTypes.Label, error) {
+	return nil, nil
+}
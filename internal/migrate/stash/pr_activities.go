@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stash
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/harness/harness-migrate/internal/common"
+	"github.com/harness/harness-migrate/internal/types"
+)
+
+// ListPullRequestActivities returns the merges, declines, reopens and
+// force pushes of the pull request.
+func (e *Export) ListPullRequestActivities(ctx context.Context, repoSlug string, prNumber int) ([]*types.PRActivity, error) {
+	e.tracer.Debug().Start(common.MsgStartExportPrActivities, repoSlug, prNumber)
+	var activities []*types.PRActivity
+	defer func() {
+		e.tracer.Debug().Stop(common.MsgCompleteExportPrActivities, len(activities), repoSlug, prNumber)
+	}()
+
+	opts := types.ListOptions{Page: 1, Size: 100}
+	for {
+		page, res, err := e.ListPRActivities(ctx, repoSlug, prNumber, opts)
+		if err != nil {
+			e.tracer.LogError(common.ErrListActivities, repoSlug, prNumber, err)
+			return nil, fmt.Errorf(common.ErrListActivities, repoSlug, prNumber, err)
+		}
+		activities = append(activities, page...)
+
+		if res.Page.Next == 0 {
+			break
+		}
+		opts.Page = res.Page.Next
+	}
+
+	// the activity log is ordered newest first.
+	sort.SliceStable(activities, func(i, j int) bool {
+		return activities[i].Created.Before(activities[j].Created)
+	})
+	return activities, nil
+}
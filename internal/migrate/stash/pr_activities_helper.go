@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stash
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/harness/harness-migrate/internal/types"
+	externalTypes "github.com/harness/harness-migrate/types"
+)
+
+// convertPRActivities converts the state changes of the activity log, and
+// the rescopes which removed commits from the source branch as force pushes.
+func convertPRActivities(from []any) []*types.PRActivity {
+	var to []*types.PRActivity
+	for i, value := range from {
+		activityMap, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		switch activityMap["action"] {
+		case "MERGED", "DECLINED", "REOPENED", "RESCOPED":
+		default:
+			continue
+		}
+
+		data, err := json.Marshal(activityMap)
+		if err != nil {
+			log.Default().Printf("Error parsing JSON from activity %d: %v", i, err)
+			continue
+		}
+		var activity prStateActivity
+		if err := json.Unmarshal(data, &activity); err != nil {
+			log.Default().Printf("Error converting state activity %d from JSON: %v", i, err)
+			continue
+		}
+
+		out := &types.PRActivity{
+			Actor:   convertParticipant(activity.User),
+			Created: time.UnixMilli(activity.CreatedDate),
+		}
+		switch activity.Action {
+		case "MERGED":
+			out.Type = externalTypes.ActivityMerged
+			if activity.Commit != nil {
+				out.To = activity.Commit.ID
+			}
+		case "DECLINED":
+			out.Type = externalTypes.ActivityClosed
+		case "REOPENED":
+			out.Type = externalTypes.ActivityReopened
+		case "RESCOPED":
+			if activity.Removed == nil || activity.Removed.Total == 0 ||
+				activity.PreviousFromHash == activity.FromHash {
+				continue
+			}
+			out.Type = externalTypes.ActivityForcePushed
+			out.From = activity.PreviousFromHash
+			out.To = activity.FromHash
+		}
+		to = append(to, out)
+	}
+	return to
+}
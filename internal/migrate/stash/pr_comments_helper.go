@@ -111,6 +111,23 @@ func convertPullRequestComment(from pullRequestComment, parentID int, anchor com
 		}},
 		ParentID:    parentID,
 		CodeComment: codeComment,
+		Resolution:  convertResolution(from, parentID),
+	}
+}
+
+// convertResolution returns the resolution of a resolved thread,
+// which is set on its root comment.
+func convertResolution(from pullRequestComment, parentID int) *types.Resolution {
+	if parentID != 0 || !from.ThreadResolved || from.ThreadResolver == nil {
+		return nil
+	}
+	return &types.Resolution{
+		Resolved: time.Unix(from.ThreadResolvedDate/1000, 0),
+		ResolvedBy: scm.User{
+			Login: from.ThreadResolver.Slug,
+			Name:  from.ThreadResolver.DisplayName,
+			Email: sanitizeEmail(from.ThreadResolver.EmailAddress, from.ThreadResolver.Slug),
+		},
 	}
 }
 
@@ -16,15 +16,62 @@ package stash
 
 import (
 	"context"
+	"fmt"
 
-	"github.com/harness/harness-migrate/internal/codeerror"
+	"github.com/harness/harness-migrate/internal/common"
 	"github.com/harness/harness-migrate/internal/types"
 )
 
+// ListPullRequestReviews returns the approvals and the requested
+// changes of the pull request reviewers and participants.
 func (e *Export) ListPullRequestReviews(ctx context.Context, repoSlug string, prNumber int, opts types.ListOptions) ([]*types.PRReview, error) {
-	return nil, &codeerror.OpNotSupportedError{Name: "ListPullRequestReviews"}
+	e.tracer.Debug().Start(common.MsgStartExportPrReviewers, repoSlug, prNumber)
+	var reviews []*types.PRReview
+	defer func() {
+		e.tracer.Debug().Stop(common.MsgCompleteExportPrReviewers, len(reviews), repoSlug, prNumber)
+	}()
+
+	participants, err := e.listParticipants(ctx, repoSlug, prNumber)
+	if err != nil {
+		e.tracer.LogError(common.ErrListReviewers, repoSlug, prNumber, err)
+		return nil, fmt.Errorf(common.ErrListReviewers, repoSlug, prNumber, err)
+	}
+
+	reviews = convertPRReviews(participants)
+	return reviews, nil
 }
 
+// ListRequestedReviewers returns the reviewers of the pull request.
 func (e *Export) ListRequestedReviewers(ctx context.Context, repoSlug string, prNumber int) ([]*types.PRReviewer, error) {
-	return nil, &codeerror.OpNotSupportedError{Name: "ListRequestedReviewers"}
+	participants, err := e.listParticipants(ctx, repoSlug, prNumber)
+	if err != nil {
+		e.tracer.LogError(common.ErrListReviewers, repoSlug, prNumber, err)
+		return nil, fmt.Errorf(common.ErrListReviewers, repoSlug, prNumber, err)
+	}
+	delete(e.participants, participantsKey(repoSlug, prNumber))
+	return convertPRReviewers(participants), nil
+}
+
+// listParticipants returns the participants of the pull request.
+// The participants listed for the reviews are kept until the
+// requested reviewers of the same pull request are listed.
+func (e *Export) listParticipants(ctx context.Context, repoSlug string, prNumber int) (*pullRequestParticipants, error) {
+	key := participantsKey(repoSlug, prNumber)
+	if participants, ok := e.participants[key]; ok {
+		return participants, nil
+	}
+
+	participants, err := e.getPRParticipants(ctx, repoSlug, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	if e.participants == nil {
+		e.participants = make(map[string]*pullRequestParticipants)
+	}
+	e.participants[key] = participants
+	return participants, nil
+}
+
+func participantsKey(repoSlug string, prNumber int) string {
+	return fmt.Sprintf("%s#%d", repoSlug, prNumber)
 }
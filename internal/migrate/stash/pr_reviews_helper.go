@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stash
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/harness/harness-migrate/internal/types"
+	"github.com/harness/harness-migrate/internal/types/enum"
+
+	"github.com/drone/go-scm/scm"
+)
+
+// convertPRReviews converts the approved and needs work statuses
+// of the reviewers and participants to reviews. Bitbucket Server
+// does not record when a status was set, the pull request update
+// time is used instead.
+func convertPRReviews(from *pullRequestParticipants) []*types.PRReview {
+	var to []*types.PRReview
+	updated := time.Unix(from.UpdatedDate/1000, 0)
+	for _, p := range append(from.Reviewers, from.Participants...) {
+		var decision enum.ReviewDecision
+		switch p.Status {
+		case "APPROVED":
+			decision = enum.ReviewDecisionApproved
+		case "NEEDS_WORK":
+			decision = enum.ReviewDecisionChangeReq
+		default:
+			continue
+		}
+
+		to = append(to, &types.PRReview{
+			Review: scm.Review{
+				ID:      p.User.ID,
+				Author:  convertParticipant(p.User),
+				Created: updated,
+				Updated: updated,
+				Sha:     p.LastReviewedCommit,
+			},
+			State: decision,
+		})
+	}
+	return to
+}
+
+func convertPRReviewers(from *pullRequestParticipants) []*types.PRReviewer {
+	var to []*types.PRReviewer
+	for _, p := range from.Reviewers {
+		to = append(to, &types.PRReviewer{User: convertParticipant(p.User)})
+	}
+	return to
+}
+
+func convertParticipant(from author) scm.User {
+	return scm.User{
+		ID:    strconv.Itoa(from.ID),
+		Login: from.Slug,
+		Name:  from.DisplayName,
+		Email: sanitizeEmail(from.EmailAddress, from.Slug),
+	}
+}
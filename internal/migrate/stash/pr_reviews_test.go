@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stash
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/harness/harness-migrate/internal/types/enum"
+)
+
+func TestConvertPRReviews(t *testing.T) {
+	var in pullRequestParticipants
+	if err := json.Unmarshal([]byte(`{
+		"updatedDate": 1704153600000,
+		"reviewers": [
+			{"user": {"id": 1, "slug": "jane", "displayName": "Jane"}, "role": "REVIEWER", "status": "APPROVED", "lastReviewedCommit": "a1b2c3"},
+			{"user": {"id": 2, "slug": "john", "displayName": "John"}, "role": "REVIEWER", "status": "UNAPPROVED"}
+		],
+		"participants": [
+			{"user": {"id": 3, "slug": "jim", "displayName": "Jim"}, "role": "PARTICIPANT", "status": "NEEDS_WORK", "lastReviewedCommit": "d4e5f6"},
+			{"user": {"id": 4, "slug": "joe", "displayName": "Joe"}, "role": "PARTICIPANT", "status": "UNAPPROVED"}
+		]
+	}`), &in); err != nil {
+		t.Fatal(err)
+	}
+
+	reviews := convertPRReviews(&in)
+	if len(reviews) != 2 {
+		t.Fatalf("want 2 reviews, got %d", len(reviews))
+	}
+	updated := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	if reviews[0].Author.Login != "jane" || reviews[0].State != enum.ReviewDecisionApproved ||
+		reviews[0].Sha != "a1b2c3" || !reviews[0].Created.Equal(updated) {
+		t.Errorf("unexpected approval %+v", reviews[0])
+	}
+	if reviews[1].Author.Login != "jim" || reviews[1].State != enum.ReviewDecisionChangeReq || reviews[1].Sha != "d4e5f6" {
+		t.Errorf("unexpected review %+v", reviews[1])
+	}
+
+	reviewers := convertPRReviewers(&in)
+	if len(reviewers) != 2 || reviewers[0].Login != "jane" || reviewers[1].Login != "john" {
+		t.Errorf("unexpected reviewers %+v", reviewers)
+	}
+}
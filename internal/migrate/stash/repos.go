@@ -128,3 +128,47 @@ func (e *Export) GetLFSEnabledSettings(ctx context.Context, repoSlug string) (bo
 	e.tracer.Stop(common.MsgCompleteRepoLFSEnabled, repoSlug)
 	return enabled, nil
 }
+
+func (e *Export) GetRepoSettings(ctx context.Context, repoSlug string) (*types.RepoSettings, error) {
+	e.tracer.Start(common.MsgStartRepoSettings, repoSlug)
+	settings, err := e.getRepoSettings(ctx, repoSlug)
+	if err != nil {
+		e.tracer.Stop(common.MsgCompleteRepoSettings, repoSlug)
+		return nil, err
+	}
+
+	e.tracer.Stop(common.MsgCompleteRepoSettings, repoSlug)
+	return settings, nil
+}
+
+// convertMergeStrategies maps the enabled bitbucket server merge
+// strategies to the harness merge methods.
+func convertMergeStrategies(from []mergeStrategy) []string {
+	var strategies []string
+	seen := map[string]bool{}
+	for _, s := range from {
+		if !s.Enabled {
+			continue
+		}
+		var methods []string
+		switch s.ID {
+		case "no-ff":
+			methods = []string{"merge"}
+		case "ff":
+			methods = []string{"merge", "fast-forward"}
+		case "ff-only":
+			methods = []string{"fast-forward"}
+		case "rebase-no-ff", "rebase-ff-only":
+			methods = []string{"rebase"}
+		case "squash", "squash-ff-only":
+			methods = []string{"squash"}
+		}
+		for _, m := range methods {
+			if !seen[m] {
+				seen[m] = true
+				strategies = append(strategies, m)
+			}
+		}
+	}
+	return strategies
+}
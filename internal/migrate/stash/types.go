@@ -94,6 +94,23 @@ type (
 		Values []interface{} `json:"values"`
 	}
 
+	// prStateActivity is a state change or rescope
+	// in the pull request activity log.
+	prStateActivity struct {
+		ID          int    `json:"id"`
+		CreatedDate int64  `json:"createdDate"`
+		User        author `json:"user"`
+		Action      string `json:"action"` // MERGED, DECLINED, REOPENED, RESCOPED
+		Commit      *struct {
+			ID string `json:"id"`
+		} `json:"commit"`
+		FromHash         string `json:"fromHash"`
+		PreviousFromHash string `json:"previousFromHash"`
+		Removed          *struct {
+			Total int `json:"total"`
+		} `json:"removed"`
+	}
+
 	pullRequestComment struct {
 		Properties struct {
 			RepositoryID int `json:"repositoryId"`
@@ -106,6 +123,9 @@ type (
 		UpdatedDate         int64                `json:"updatedDate"`
 		Comments            []pullRequestComment `json:"comments"`
 		Tasks               []interface{}        `json:"tasks"`
+		ThreadResolved      bool                 `json:"threadResolved"`
+		ThreadResolvedDate  int64                `json:"threadResolvedDate"`
+		ThreadResolver      *author              `json:"threadResolver"`
 		PermittedOperations struct {
 			Editable  bool `json:"editable"`
 			Deletable bool `json:"deletable"`
@@ -171,4 +191,38 @@ type (
 		modelBranch
 		Prefix string
 	}
+
+	repository struct {
+		Description string `json:"description"`
+	}
+
+	mergeStrategy struct {
+		ID      string `json:"id"`
+		Enabled bool   `json:"enabled"`
+	}
+
+	pullRequestSettings struct {
+		MergeConfig struct {
+			Strategies []mergeStrategy `json:"strategies"`
+		} `json:"mergeConfig"`
+	}
+
+	pullRequestCleanup struct {
+		DeleteSourceBranch bool `json:"deleteSourceBranch"`
+	}
+
+	// pullRequestParticipants are the reviewers and the
+	// participants of a pull request.
+	pullRequestParticipants struct {
+		UpdatedDate  int64         `json:"updatedDate"`
+		Reviewers    []participant `json:"reviewers"`
+		Participants []participant `json:"participants"`
+	}
+
+	participant struct {
+		User               author `json:"user"`
+		Role               string `json:"role"`   // AUTHOR, REVIEWER, PARTICIPANT
+		Status             string `json:"status"` // APPROVED, NEEDS_WORK, UNAPPROVED
+		LastReviewedCommit string `json:"lastReviewedCommit"`
+	}
 )
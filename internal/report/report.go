@@ -30,6 +30,12 @@ const (
 	ReportTypeLabels        = "labels"
 	ReportTypeUsers         = "users"
 	ReportTypeGitLFSObjects = "LFS objects"
+	ReportTypeSecrets       = "secrets"
+	ReportTypeRepoSettings  = "repository settings"
+	ReportTypeMapping       = "mapping"
+	ReportTypeAttachments   = "attachments"
+	ReportTypeIdentities    = "rewritten commits"
+	ReportTypeActivities    = "pull request activities"
 )
 
 type Report struct {
@@ -37,6 +43,15 @@ type Report struct {
 	report  map[string]int
 	errors  map[string]*Error
 	skipped map[string]bool
+	details []*Detail
+}
+
+// Detail is an informational entry listed below the
+// summary table, such as an item requiring follow-up.
+type Detail struct {
+	Type   string
+	Key    string
+	Detail string
 }
 
 type Error struct {
@@ -61,11 +76,10 @@ func (r *Report) ReportMetric(typ string, value int) {
 // If a key is reported twice it will be overwritten.
 func (r *Report) ReportError(typ string, key string, error string) {
 	m, ok := r.errors[typ]
-	if ok {
-		m.error[key] = error
+	if !ok {
+		m = &Error{error: make(map[string]string)}
+		r.errors[typ] = m
 	}
-	r.errors[typ] = &Error{error: make(map[string]string)}
-	m = r.errors[typ]
 	m.error[key] = error
 }
 
@@ -75,14 +89,19 @@ func (r *Report) ReportErrors(typ string, key string, errors []string) {
 	}
 
 	m, ok := r.errors[typ]
-	if ok {
-		m.error[key] = strings.Join(errors, ",")
+	if !ok {
+		m = &Error{error: make(map[string]string)}
+		r.errors[typ] = m
 	}
-	r.errors[typ] = &Error{error: make(map[string]string)}
-	m = r.errors[typ]
 	m.error[key] = strings.Join(errors, ",")
 }
 
+// ReportDetail records an informational entry for a type and key.
+// Details are listed in the order they are reported.
+func (r *Report) ReportDetail(typ string, key string, detail string) {
+	r.details = append(r.details, &Detail{Type: typ, Key: key, Detail: detail})
+}
+
 // ReportSkipped marks a metadata type as skipped during migration
 func (r *Report) ReportSkipped(typ string) {
 	r.skipped[typ] = true
@@ -133,4 +152,21 @@ func (r *Report) publishReport() {
 		{Number: 1, AlignHeader: text.AlignCenter},
 	})
 	t.Render()
+
+	r.publishDetails()
+}
+
+func (r *Report) publishDetails() {
+	if len(r.details) == 0 {
+		return
+	}
+	t := table.NewWriter()
+	t.SetTitle(r.name)
+	t.AppendHeader(table.Row{"Type", "Item", "Detail"})
+	for _, d := range r.details {
+		t.AppendRow(table.Row{d.Type, d.Key, d.Detail})
+	}
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(table.StyleLight)
+	t.Render()
 }
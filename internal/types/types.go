@@ -29,8 +29,9 @@ type (
 	Org struct {
 		Name string `json:"name"`
 
-		Projects []*Project `json:"project,omitempty"`
-		Secrets  []*Secret  `json:"secrets,omitempty"`
+		Projects  []*Project  `json:"project,omitempty"`
+		Secrets   []*Secret   `json:"secrets,omitempty"`
+		Variables []*Variable `json:"variables,omitempty"`
 	}
 
 	// Project defines a project.
@@ -43,6 +44,7 @@ type (
 		Yaml   []byte `json:"yaml"`
 
 		Secrets   []*Secret   `json:"secrets,omitempty"`
+		Variables []*Variable `json:"variables,omitempty"`
 		Pipelines []*Pipeline `json:"pipelines,omitempty"`
 	}
 
@@ -60,6 +62,17 @@ type (
 		Name  string `json:"name"`
 		Desc  string `json:"desc,omitempty"`
 		Value string `json:"value,omitempty"`
+
+		// Placeholder is true when the secret value cannot be
+		// read from the source system. The secret is created
+		// with a placeholder value that must be updated.
+		Placeholder bool `json:"placeholder,omitempty"`
+	}
+
+	// Variable defines a plain text variable.
+	Variable struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
 	}
 
 	PullRequestListOptions struct {
@@ -75,6 +88,24 @@ type (
 		IsEmpty        bool
 		LfsObjectCount int
 		GitLFSDisabled bool
+		Settings       *RepoSettings
+	}
+
+	// RepoSettings defines repository properties that are
+	// not part of the scm repository.
+	RepoSettings struct {
+		Description string
+		Topics      []string
+		Homepage    string
+		Merge       *MergeSettings
+	}
+
+	// MergeSettings defines the repository-wide pull
+	// request merge settings.
+	MergeSettings struct {
+		StrategiesAllowed []string
+		DeleteBranch      bool
+		TitleFormat       string
 	}
 
 	LabelResponse struct {
@@ -91,6 +122,14 @@ type (
 
 	PRResponse struct {
 		scm.PullRequest
+
+		// Assignees, Milestone, Squash and AutoMerge are not
+		// part of scm.PullRequest and are set by the exporters
+		// that support them.
+		Assignees []scm.User `json:"assignees,omitempty"`
+		Milestone string     `json:"milestone,omitempty"`
+		Squash    bool       `json:"squash,omitempty"`
+		AutoMerge bool       `json:"auto_merge,omitempty"`
 	}
 
 	WebhookData struct {
@@ -124,6 +163,13 @@ type (
 		scm.Comment
 		ParentID    int
 		CodeComment *CodeComment
+		Resolution  *Resolution // set on the first comment of a resolved thread
+	}
+
+	// Resolution is the resolution of a review thread.
+	Resolution struct {
+		Resolved   time.Time
+		ResolvedBy scm.User
 	}
 
 	PRReview struct {
@@ -136,11 +182,22 @@ type (
 		// skip team/user groups assigned as reviewers
 	}
 
+	// PRActivity is an event on the timeline of a pull request.
+	PRActivity struct {
+		Type    types.ActivityType
+		Actor   scm.User
+		Created time.Time
+		From    string
+		To      string
+		Method  string
+	}
+
 	PullRequestData struct {
 		PullRequest PRResponse
 		Comments    []*PRComment
 		Reviews     []*PRReview
 		Reviewers   []*PRReviewer
+		Activities  []*PRActivity
 	}
 
 	BranchRule struct {
@@ -0,0 +1,309 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package users
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/harness/harness-migrate/internal/gitexporter"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Rule types of the user mapping.
+const (
+	RuleExact  = "exact"
+	RuleDomain = "domain"
+	RuleRegex  = "regex"
+	RuleLookup = "lookup"
+)
+
+type (
+	// UserMapping represents the mapping between old and new email addresses
+	UserMapping map[string]string
+
+	// Mapping maps the users of the exported zip file to new emails. Rules
+	// are applied in order and the first matching rule wins. The default
+	// email replaces fallback emails generated by the exporter which are
+	// not matched by any rule.
+	Mapping struct {
+		Rules   []*Rule `yaml:"rules"`
+		Default string  `yaml:"default"`
+	}
+
+	// Rule maps the users matching the rule:
+	//   - exact: email from is replaced by to.
+	//   - domain: emails of domain from are moved to domain to.
+	//   - regex: emails matching from are replaced by to, which
+	//     can reference capture groups as $1 or ${name}.
+	//   - lookup: users are looked up by login in the csv file,
+	//     using the login and email columns of its header row.
+	Rule struct {
+		Type        string `yaml:"type"`
+		From        string `yaml:"from"`
+		To          string `yaml:"to"`
+		File        string `yaml:"file"`
+		LoginColumn string `yaml:"login_column"`
+		EmailColumn string `yaml:"email_column"`
+
+		exact  map[string]string
+		regex  *regexp.Regexp
+		logins map[string]string
+	}
+)
+
+// Map returns the new email of the user, and false if the
+// email does not change.
+func (m *Mapping) Map(login, email string) (string, bool) {
+	for _, rule := range m.Rules {
+		if to := rule.apply(login, email); to != "" {
+			return to, to != email
+		}
+	}
+	if m.Default != "" && (email == "" || strings.HasSuffix(email, gitexporter.UnknownEmailSuffix)) {
+		return m.Default, m.Default != email
+	}
+	return email, false
+}
+
+// MapIdentity maps the email of a commit identity and keeps its name.
+func (m *Mapping) MapIdentity(name, email string) (string, string) {
+	email, _ = m.Map("", email)
+	return name, email
+}
+
+// apply returns the new email, or an empty string if the rule
+// does not match.
+func (r *Rule) apply(login, email string) string {
+	switch r.Type {
+	case RuleExact:
+		return r.exact[email]
+	case RuleDomain:
+		local, domain, ok := strings.Cut(email, "@")
+		if ok && strings.EqualFold(domain, r.From) {
+			return local + "@" + r.To
+		}
+	case RuleRegex:
+		if match := r.regex.FindStringSubmatchIndex(email); match != nil {
+			return string(r.regex.ExpandString(nil, r.To, email, match))
+		}
+	case RuleLookup:
+		if login != "" {
+			return r.logins[strings.ToLower(login)]
+		}
+	}
+	return ""
+}
+
+// LoadMapping reads the user mapping file. It can be a json map of old to new
+// emails, a template generated by the users template command, or a yaml file
+// or json file with a top-level rules key with mapping rules.
+func LoadMapping(file string) (*Mapping, error) {
+	mapping, err := loadMapping(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user mapping %q: %w", file, err)
+	}
+	return mapping, nil
+}
+
+func loadMapping(file string) (*Mapping, error) {
+	if isCSV(file) {
+		users, err := loadTemplateCSV(file)
+		if err != nil {
+			return nil, err
+		}
+		return exactMapping(users), nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isRulesMapping(file, data) {
+		var users UserMapping
+		if err := json.Unmarshal(data, &users); err == nil {
+			return exactMapping(users), nil
+		}
+
+		var identities []*Identity
+		if err := json.Unmarshal(data, &identities); err == nil {
+			users = make(UserMapping)
+			for _, identity := range identities {
+				if identity.NewEmail != "" {
+					users[identity.Email] = identity.NewEmail
+				}
+			}
+			return exactMapping(users), nil
+		}
+	}
+
+	mapping := new(Mapping)
+	if err := yaml.UnmarshalStrict(data, mapping); err != nil {
+		return nil, err
+	}
+	for i, rule := range mapping.Rules {
+		if err := rule.compile(filepath.Dir(file)); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+	return mapping, nil
+}
+
+// isRulesMapping returns true if the file is a yaml file or a json
+// object with a top-level rules key.
+func isRulesMapping(file string, data []byte) bool {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return false
+	}
+	_, ok := keys["rules"]
+	return ok
+}
+
+func exactMapping(users UserMapping) *Mapping {
+	return &Mapping{Rules: []*Rule{{Type: RuleExact, exact: users}}}
+}
+
+// compile validates the rule and prepares it to be applied. Lookup
+// files are relative to the directory of the mapping file.
+func (r *Rule) compile(dir string) error {
+	switch r.Type {
+	case RuleExact:
+		if r.From == "" || r.To == "" {
+			return fmt.Errorf("%s rule requires from and to", r.Type)
+		}
+		r.exact = map[string]string{r.From: r.To}
+	case RuleDomain:
+		if r.From == "" || r.To == "" {
+			return fmt.Errorf("%s rule requires from and to", r.Type)
+		}
+		r.From = strings.TrimPrefix(r.From, "@")
+		r.To = strings.TrimPrefix(r.To, "@")
+	case RuleRegex:
+		if r.From == "" || r.To == "" {
+			return fmt.Errorf("%s rule requires from and to", r.Type)
+		}
+		regex, err := regexp.Compile(r.From)
+		if err != nil {
+			return err
+		}
+		r.regex = regex
+	case RuleLookup:
+		if r.File == "" {
+			return fmt.Errorf("%s rule requires file", r.Type)
+		}
+		file := r.File
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		logins, err := loadLookupCSV(file, r.LoginColumn, r.EmailColumn)
+		if err != nil {
+			return err
+		}
+		r.logins = logins
+	default:
+		return fmt.Errorf("unknown rule type %q", r.Type)
+	}
+	return nil
+}
+
+// loadLookupCSV reads the emails by lowercase login from a csv file
+// with a header row. Columns default to login and email.
+func loadLookupCSV(file, loginColumn, emailColumn string) (map[string]string, error) {
+	if loginColumn == "" {
+		loginColumn = "login"
+	}
+	if emailColumn == "" {
+		emailColumn = "email"
+	}
+
+	records, err := readCSV(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s has no header row", file)
+	}
+
+	loginIndex, emailIndex := -1, -1
+	for i, column := range records[0] {
+		switch {
+		case strings.EqualFold(strings.TrimSpace(column), loginColumn):
+			loginIndex = i
+		case strings.EqualFold(strings.TrimSpace(column), emailColumn):
+			emailIndex = i
+		}
+	}
+	if loginIndex < 0 || emailIndex < 0 {
+		return nil, fmt.Errorf("%s has no %q and %q columns", file, loginColumn, emailColumn)
+	}
+
+	logins := make(map[string]string)
+	for _, record := range records[1:] {
+		if loginIndex >= len(record) || emailIndex >= len(record) {
+			continue
+		}
+		login := strings.ToLower(strings.TrimSpace(record[loginIndex]))
+		email := strings.TrimSpace(record[emailIndex])
+		if login != "" && email != "" {
+			logins[login] = email
+		}
+	}
+	return logins, nil
+}
+
+// loadTemplateCSV loads the user mapping from the email and
+// new_email columns of a csv template.
+func loadTemplateCSV(file string) (UserMapping, error) {
+	records, err := readCSV(file)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(UserMapping)
+	for i, record := range records {
+		if i == 0 && len(record) > 0 && record[0] == templateHeader[0] {
+			continue
+		}
+		if len(record) < 2 || strings.TrimSpace(record[1]) == "" {
+			continue
+		}
+		users[strings.TrimSpace(record[0])] = strings.TrimSpace(record[1])
+	}
+	return users, nil
+}
+
+func readCSV(file string) ([][]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	return r.ReadAll()
+}
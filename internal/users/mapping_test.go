@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package users
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMappingRules(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "hr.csv"), "Username,Work Email\njdoe,john.doe@acme.com\n")
+	writeTestFile(t, filepath.Join(dir, "users.yaml"), `
+rules:
+  - type: exact
+    from: ceo@startup.io
+    to: jane.roe@acme.com
+  - type: domain
+    from: startup.io
+    to: acme.com
+  - type: regex
+    from: ^(?:\d+\+)?([^@]+)@users\.noreply\.github\.com$
+    to: $1@acme.com
+  - type: lookup
+    file: hr.csv
+    login_column: username
+    email_column: work email
+default: migrated@acme.com
+`)
+
+	mapping, err := LoadMapping(filepath.Join(dir, "users.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		login, email, want string
+		changed            bool
+	}{
+		{"", "ceo@startup.io", "jane.roe@acme.com", true},
+		{"", "dev@Startup.io", "dev@acme.com", true},
+		{"octocat", "12345+octocat@users.noreply.github.com", "octocat@acme.com", true},
+		{"JDoe", "jdoe@unknownemail.harness.io", "john.doe@acme.com", true},
+		{"ghost", "ghost@unknownemail.harness.io", "migrated@acme.com", true},
+		{"", "john.doe@acme.com", "john.doe@acme.com", false},
+	}
+	for _, test := range tests {
+		got, changed := mapping.Map(test.login, test.email)
+		if got != test.want || changed != test.changed {
+			t.Errorf("Want %q (%v) for %q, got %q (%v)", test.want, test.changed, test.email, got, changed)
+		}
+	}
+}
+
+func TestLoadMappingFormats(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "map.json"), `{"old@example.com": "new@example.com"}`)
+	writeTestFile(t, filepath.Join(dir, "template.json"), `[{"email": "old@example.com", "new_email": "new@example.com"}, {"email": "same@example.com", "new_email": ""}]`)
+	writeTestFile(t, filepath.Join(dir, "default.json"), `{"rules": [], "default": "new@example.com"}`)
+	writeTestFile(t, filepath.Join(dir, "default.yaml"), `default: new@example.com`)
+
+	for _, file := range []string{"map.json", "template.json"} {
+		mapping, err := LoadMapping(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := mapping.Map("", "old@example.com"); got != "new@example.com" {
+			t.Errorf("Want mapped email from %s, got %q", file, got)
+		}
+		if _, changed := mapping.Map("", "same@example.com"); changed {
+			t.Errorf("Want unmapped email from %s to be kept", file)
+		}
+	}
+
+	for _, file := range []string{"default.json", "default.yaml"} {
+		mapping, err := LoadMapping(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := mapping.Map("", ""); got != "new@example.com" {
+			t.Errorf("Want default email for users without email from %s, got %q", file, got)
+		}
+	}
+
+	writeTestFile(t, filepath.Join(dir, "invalid.yaml"), "rules:\n  - type: prefix\n")
+	if _, err := LoadMapping(filepath.Join(dir, "invalid.yaml")); err == nil {
+		t.Errorf("Want error for unknown rule type")
+	}
+}
+
+func writeTestFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -26,7 +26,7 @@ import (
 )
 
 // processPullRequests updates user emails in pull request files
-func (u *Updater) processPullRequests(repoDir string, mapping UserMapping) error {
+func (u *Updater) processPullRequests(repoDir, repoName string, mapping *Mapping) error {
 	prDir := filepath.Join(repoDir, types.PullRequestDir)
 	if _, err := os.Stat(prDir); errors.Is(err, os.ErrNotExist) {
 		return nil
@@ -42,7 +42,7 @@ func (u *Updater) processPullRequests(repoDir string, mapping UserMapping) error
 	}
 
 	for _, prFile := range prFiles {
-		if err := u.processPRFile(prFile, mapping); err != nil {
+		if err := u.processPRFile(prFile, repoName, mapping); err != nil {
 			u.tracer.Log("Error processing PR file %s: %v", prFile, err)
 			continue
 		}
@@ -52,7 +52,7 @@ func (u *Updater) processPullRequests(repoDir string, mapping UserMapping) error
 }
 
 // processPRFile processes a single PR JSON file and updates user emails
-func (u *Updater) processPRFile(filePath string, mapping UserMapping) error {
+func (u *Updater) processPRFile(filePath, repoName string, mapping *Mapping) error {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to open PR file: %w", err)
@@ -70,29 +70,45 @@ func (u *Updater) processPRFile(filePath string, mapping UserMapping) error {
 
 	for i := range prEntries {
 		entryUpdated := false
+		pr := fmt.Sprintf("pr #%d", prEntries[i].PullRequest.Number)
 
-		if newEmail, exists := mapping[prEntries[i].PullRequest.Author.Email]; exists {
-			prEntries[i].PullRequest.Author.Email = newEmail
+		if u.remap(mapping, repoName, pr+" author", &prEntries[i].PullRequest.Author) {
 			entryUpdated = true
 		}
 
+		for j := range prEntries[i].PullRequest.Assignees {
+			if u.remap(mapping, repoName, pr+" assignee", &prEntries[i].PullRequest.Assignees[j]) {
+				entryUpdated = true
+			}
+		}
+
 		for j := range prEntries[i].Comments {
-			if newEmail, exists := mapping[prEntries[i].Comments[j].Author.Email]; exists {
-				prEntries[i].Comments[j].Author.Email = newEmail
+			comment := fmt.Sprintf("%s comment %d", pr, prEntries[i].Comments[j].ID)
+			if u.remap(mapping, repoName, comment+" author", &prEntries[i].Comments[j].Author) {
 				entryUpdated = true
 			}
+			if resolver := prEntries[i].Comments[j].ResolvedBy; resolver != nil {
+				if u.remap(mapping, repoName, comment+" resolver", resolver) {
+					entryUpdated = true
+				}
+			}
 		}
 
 		for j := range prEntries[i].Reviews {
-			if newEmail, exists := mapping[prEntries[i].Reviews[j].Author.Email]; exists {
-				prEntries[i].Reviews[j].Author.Email = newEmail
+			review := fmt.Sprintf("%s review %d", pr, prEntries[i].Reviews[j].ID)
+			if u.remap(mapping, repoName, review+" author", &prEntries[i].Reviews[j].Author) {
+				entryUpdated = true
+			}
+		}
+
+		for j := range prEntries[i].Activities {
+			if u.remap(mapping, repoName, pr+" activity actor", &prEntries[i].Activities[j].Actor) {
 				entryUpdated = true
 			}
 		}
 
 		for j := range prEntries[i].Reviewers {
-			if newEmail, exists := mapping[prEntries[i].Reviewers[j].User.Email]; exists {
-				prEntries[i].Reviewers[j].User.Email = newEmail
+			if u.remap(mapping, repoName, pr+" reviewer", &prEntries[i].Reviewers[j].User) {
 				entryUpdated = true
 			}
 		}
@@ -105,7 +121,7 @@ func (u *Updater) processPRFile(filePath string, mapping UserMapping) error {
 		updated = updated || entryUpdated
 	}
 
-	if updated {
+	if updated && !u.dryRun {
 		prJson, err := util.GetJson(prEntries)
 		if err != nil {
 			return fmt.Errorf("cannot serialize pull requests data into json: %w", err)
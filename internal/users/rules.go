@@ -26,13 +26,13 @@ import (
 )
 
 // processRules updates user emails in branch rules files
-func (u *Updater) processRules(repoDir string, mapping UserMapping) error {
+func (u *Updater) processRules(repoDir, repoName string, mapping *Mapping) error {
 	rulesFile := filepath.Join(repoDir, types.BranchRulesFileName)
 	if _, err := os.Stat(rulesFile); errors.Is(err, os.ErrNotExist) {
 		return nil
 	}
 
-	updated, err := u.processRuleFile(rulesFile, mapping)
+	updated, err := u.processRuleFile(rulesFile, repoName, mapping)
 	if err != nil {
 		u.tracer.Log("Error processing rules file %s: %v", rulesFile, err)
 		return fmt.Errorf("error processing rules file %s: %w", rulesFile, err)
@@ -46,7 +46,7 @@ func (u *Updater) processRules(repoDir string, mapping UserMapping) error {
 }
 
 // processRuleFile processes a single rule JSON file and updates user emails
-func (u *Updater) processRuleFile(filePath string, mapping UserMapping) (bool, error) {
+func (u *Updater) processRuleFile(filePath, repoName string, mapping *Mapping) (bool, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return false, fmt.Errorf("failed to read rule file: %w", err)
@@ -66,19 +66,18 @@ func (u *Updater) processRuleFile(filePath string, mapping UserMapping) (bool, e
 		updatedEmails := make([]string, 0, len(rule.Definition.Bypass.UserEmails))
 
 		for _, email := range rule.Definition.Bypass.UserEmails {
-			if newEmail, exists := mapping[email]; exists {
+			user := &types.User{Email: email}
+			if u.remap(mapping, repoName, fmt.Sprintf("branch rule %s bypass", rule.Identifier), user) {
 				updated = true
-				updatedEmails = append(updatedEmails, newEmail)
-			} else {
-				updatedEmails = append(updatedEmails, email)
 			}
+			updatedEmails = append(updatedEmails, user.Email)
 		}
 
 		rule.Definition.Bypass.UserEmails = updatedEmails
 	}
 
-	if !updated {
-		return false, nil
+	if !updated || u.dryRun {
+		return updated, nil
 	}
 
 	rulesJson, err := util.GetJson(rules)
@@ -0,0 +1,310 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package users
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/harness/harness-migrate/internal/gitexporter"
+	"github.com/harness/harness-migrate/internal/harness"
+	"github.com/harness/harness-migrate/internal/tracer"
+	"github.com/harness/harness-migrate/internal/util"
+	"github.com/harness/harness-migrate/types"
+)
+
+// templateHeader is the header of the csv user mapping template.
+var templateHeader = []string{"email", "new_email", "login", "name", "fallback", "exists", "occurrences", "repositories"}
+
+// Identity is a user found in the exported zip file.
+type Identity struct {
+	Email    string `json:"email"`
+	NewEmail string `json:"new_email"`
+	Login    string `json:"login,omitempty"`
+	Name     string `json:"name,omitempty"`
+
+	// Fallback is true when the exporter could not find the
+	// email of the user and generated one.
+	Fallback bool `json:"fallback"`
+
+	// Exists is set when the users are checked against Harness.
+	Exists *bool `json:"exists,omitempty"`
+
+	// Occurrences is the number of times the user appears per repository.
+	Occurrences map[string]int `json:"occurrences"`
+}
+
+// Template generates a user mapping template from the exported zip file.
+type Template struct {
+	zipFilePath string
+	outputPath  string
+	harness     harness.Client // optional, to check which users exist
+	tracer      tracer.Tracer
+}
+
+// NewTemplate creates a new instance of the Template
+func NewTemplate(zipFilePath, outputPath string, client harness.Client, tracer tracer.Tracer) *Template {
+	return &Template{
+		zipFilePath: zipFilePath,
+		outputPath:  outputPath,
+		harness:     client,
+		tracer:      tracer,
+	}
+}
+
+// Generate lists the users of the zip file and writes the template.
+func (t *Template) Generate(ctx context.Context) error {
+	tempDir, err := os.MkdirTemp("", "harness-users")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	t.tracer.Log("Extracting the zip file from %s", t.zipFilePath)
+
+	if err := util.Unzip(t.zipFilePath, tempDir); err != nil {
+		return fmt.Errorf("failed to extract zip file: %w", err)
+	}
+
+	identities, err := listIdentities(tempDir)
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+
+	t.tracer.Log("Found %d users", len(identities))
+
+	if t.harness != nil {
+		if err := t.checkIdentities(identities); err != nil {
+			return err
+		}
+	}
+
+	if err := writeTemplate(t.outputPath, identities); err != nil {
+		return fmt.Errorf("failed to write user mapping template: %w", err)
+	}
+
+	t.tracer.Log("Successfully wrote user mapping template to %s", t.outputPath)
+	return nil
+}
+
+// checkIdentities marks which users already exist in Harness.
+func (t *Template) checkIdentities(identities []*Identity) error {
+	emails := make([]string, len(identities))
+	for i, identity := range identities {
+		emails[i] = identity.Email
+	}
+
+	out, err := t.harness.CheckUsers(&types.CheckUsersInput{Emails: emails})
+	if err != nil {
+		return fmt.Errorf("users cannot be checked in harness platform: %w", err)
+	}
+
+	unknown := make(map[string]bool, len(out.UnknownEmails))
+	for _, email := range out.UnknownEmails {
+		unknown[strings.ToLower(email)] = true
+	}
+	for _, identity := range identities {
+		exists := !unknown[strings.ToLower(identity.Email)]
+		identity.Exists = &exists
+	}
+	return nil
+}
+
+// listIdentities returns the users of all repositories in the extracted
+// zip file, with users which have a fallback email first.
+func listIdentities(rootDir string) ([]*Identity, error) {
+	identities := make(map[string]*Identity)
+	add := func(repo string, user *types.User, email string) {
+		if user != nil {
+			email = user.Email
+		}
+		if email == "" {
+			return
+		}
+
+		identity, ok := identities[email]
+		if !ok {
+			identity = &Identity{
+				Email:       email,
+				Fallback:    strings.HasSuffix(email, gitexporter.UnknownEmailSuffix),
+				Occurrences: make(map[string]int),
+			}
+			identities[email] = identity
+		}
+		if user != nil && identity.Login == "" {
+			identity.Login = user.Login
+		}
+		if user != nil && identity.Name == "" {
+			identity.Name = user.Name
+		}
+		identity.Occurrences[repo]++
+	}
+
+	err := filepath.WalkDir(rootDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != types.InfoFileName {
+			return nil
+		}
+		return collectRepoUsers(filepath.Dir(path), add)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*Identity, 0, len(identities))
+	for _, identity := range identities {
+		out = append(out, identity)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Fallback != out[j].Fallback {
+			return out[i].Fallback
+		}
+		return out[i].Email < out[j].Email
+	})
+	return out, nil
+}
+
+// collectRepoUsers calls add for every user of the pull requests and branch
+// rules of a repository.
+func collectRepoUsers(repoDir string, add func(repo string, user *types.User, email string)) error {
+	var repo types.Repository
+	if err := readJson(filepath.Join(repoDir, types.InfoFileName), &repo); err != nil {
+		return err
+	}
+
+	prFiles, err := filepath.Glob(filepath.Join(repoDir, types.PullRequestDir, "pr[0-9]*.json"))
+	if err != nil {
+		return fmt.Errorf("error listing PR files in %s: %w", repoDir, err)
+	}
+	for _, prFile := range prFiles {
+		var prs []types.PullRequestData
+		if err := readJson(prFile, &prs); err != nil {
+			return err
+		}
+		for i := range prs {
+			add(repo.Slug, &prs[i].PullRequest.Author, "")
+			for j := range prs[i].PullRequest.Assignees {
+				add(repo.Slug, &prs[i].PullRequest.Assignees[j], "")
+			}
+			for j := range prs[i].Comments {
+				add(repo.Slug, &prs[i].Comments[j].Author, "")
+				if prs[i].Comments[j].ResolvedBy != nil {
+					add(repo.Slug, prs[i].Comments[j].ResolvedBy, "")
+				}
+			}
+			for j := range prs[i].Reviews {
+				add(repo.Slug, &prs[i].Reviews[j].Author, "")
+			}
+			for j := range prs[i].Reviewers {
+				add(repo.Slug, &prs[i].Reviewers[j].User, "")
+			}
+			for j := range prs[i].Activities {
+				add(repo.Slug, &prs[i].Activities[j].Actor, "")
+			}
+		}
+	}
+
+	rulesFile := filepath.Join(repoDir, types.BranchRulesFileName)
+	if _, err := os.Stat(rulesFile); err == nil {
+		var rules []*types.BranchRule
+		if err := readJson(rulesFile, &rules); err != nil {
+			return err
+		}
+		for _, rule := range rules {
+			for _, email := range rule.Definition.Bypass.UserEmails {
+				add(repo.Slug, nil, email)
+			}
+		}
+	}
+	return nil
+}
+
+func readJson(path string, out any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeTemplate writes the users as csv or, for any other
+// file extension, as json.
+func writeTemplate(path string, identities []*Identity) error {
+	if !isCSV(path) {
+		data, err := json.MarshalIndent(identities, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, data, 0644)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write(templateHeader); err != nil {
+		return err
+	}
+	for _, identity := range identities {
+		var exists string
+		if identity.Exists != nil {
+			exists = strconv.FormatBool(*identity.Exists)
+		}
+
+		total := 0
+		repos := make([]string, 0, len(identity.Occurrences))
+		for repo, count := range identity.Occurrences {
+			total += count
+			repos = append(repos, fmt.Sprintf("%s=%d", repo, count))
+		}
+		sort.Strings(repos)
+
+		err := w.Write([]string{
+			identity.Email,
+			identity.NewEmail,
+			identity.Login,
+			identity.Name,
+			strconv.FormatBool(identity.Fallback),
+			exists,
+			strconv.Itoa(total),
+			strings.Join(repos, ";"),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func isCSV(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".csv")
+}
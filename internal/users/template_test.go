@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package users
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harness/harness-migrate/internal/util"
+	"github.com/harness/harness-migrate/types"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListIdentities(t *testing.T) {
+	dir := t.TempDir()
+	repoDir := filepath.Join(dir, "acme", "api")
+	writeTestJson(t, filepath.Join(repoDir, types.InfoFileName), types.Repository{Slug: "acme/api"})
+
+	jane := types.User{Login: "jane", Name: "Jane Doe", Email: "jane@example.com"}
+	ghost := types.User{Login: "ghost", Email: "ghost@unknownemail.harness.io"}
+	writeTestJson(t, filepath.Join(repoDir, types.PullRequestDir, "pr0.json"), []types.PullRequestData{{
+		PullRequest: types.PullRequest{Author: jane},
+		Comments: []types.Comment{
+			{Author: ghost, ResolvedBy: &jane},
+			{Author: types.User{Login: "nobody"}},
+		},
+		Reviewers: []types.Reviewer{{User: ghost}},
+	}})
+
+	got, err := listIdentities(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Identity{
+		{Email: ghost.Email, Login: "ghost", Fallback: true, Occurrences: map[string]int{"acme/api": 2}},
+		{Email: jane.Email, Login: "jane", Name: "Jane Doe", Occurrences: map[string]int{"acme/api": 2}},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected identities")
+		t.Log(diff)
+	}
+
+	// a template filled in by the user is loaded as a user mapping.
+	got[0].NewEmail = "ghost@example.com"
+	path := filepath.Join(dir, "users.csv")
+	if err := writeTemplate(path, got); err != nil {
+		t.Fatal(err)
+	}
+	mapping, err := loadTemplateCSV(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(mapping, UserMapping{ghost.Email: "ghost@example.com"}); diff != "" {
+		t.Errorf("Unexpected user mapping")
+		t.Log(diff)
+	}
+}
+
+func writeTestJson(t *testing.T, path string, v any) {
+	t.Helper()
+	data, err := util.GetJson(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
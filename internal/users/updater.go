@@ -27,35 +27,61 @@ import (
 	"github.com/harness/harness-migrate/types"
 )
 
-// UserMapping represents the mapping between old and new email addresses
-type UserMapping map[string]string
-
 // Updater handles the process of updating user emails in the exported zip file
 type Updater struct {
 	userMappingPath string
 	zipFilePath     string
+	dryRun          bool
 	tracer          tracer.Tracer
+
+	rewrites []*Rewrite
+}
+
+// Rewrite is an email replaced in the exported zip file.
+type Rewrite struct {
+	Repository string
+	Location   string
+	From       string
+	To         string
 }
 
 // NewUpdater creates a new instance of the Updater
-func NewUpdater(userMappingPath, zipFilePath string, tracer tracer.Tracer) *Updater {
+func NewUpdater(userMappingPath, zipFilePath string, dryRun bool, tracer tracer.Tracer) *Updater {
 	return &Updater{
 		userMappingPath: userMappingPath,
 		zipFilePath:     zipFilePath,
+		dryRun:          dryRun,
 		tracer:          tracer,
 	}
 }
 
+// Rewrites returns the emails replaced, or to be replaced
+// in a dry run.
+func (u *Updater) Rewrites() []*Rewrite {
+	return u.rewrites
+}
+
+// remap replaces the email of the user and records the rewrite.
+func (u *Updater) remap(mapping *Mapping, repo, location string, user *types.User) bool {
+	newEmail, ok := mapping.Map(user.Login, user.Email)
+	if !ok {
+		return false
+	}
+	u.rewrites = append(u.rewrites, &Rewrite{Repository: repo, Location: location, From: user.Email, To: newEmail})
+	user.Email = newEmail
+	return true
+}
+
 // Update performs the user email update process
 func (u *Updater) Update(ctx context.Context) error {
 	u.tracer.Log("Loading user mapping from %s", u.userMappingPath)
 
-	mapping, err := u.loadUserMapping()
+	mapping, err := LoadMapping(u.userMappingPath)
 	if err != nil {
-		return fmt.Errorf("failed to load user mapping: %w", err)
+		return err
 	}
 
-	u.tracer.Log("Found %d user mappings", len(mapping))
+	u.tracer.Log("Found %d user mapping rules", len(mapping.Rules))
 
 	tempDir := "harness-updated"
 	err = util.CreateFolder(tempDir)
@@ -74,6 +100,11 @@ func (u *Updater) Update(ctx context.Context) error {
 		return fmt.Errorf("failed to process files: %w", err)
 	}
 
+	if u.dryRun {
+		u.tracer.Log("Dry run, %d user emails would be updated in %s", len(u.rewrites), u.zipFilePath)
+		return nil
+	}
+
 	updatedZipPath := u.zipFilePath + ".updated"
 	if err := util.ZipFolder(tempDir, updatedZipPath); err != nil {
 		return fmt.Errorf("failed to create updated zip file: %w", err)
@@ -87,23 +118,8 @@ func (u *Updater) Update(ctx context.Context) error {
 	return nil
 }
 
-// loadUserMapping loads the user mapping from the JSON file
-func (u *Updater) loadUserMapping() (UserMapping, error) {
-	data, err := os.ReadFile(u.userMappingPath)
-	if err != nil {
-		return nil, err
-	}
-
-	var mapping UserMapping
-	if err := json.Unmarshal(data, &mapping); err != nil {
-		return nil, err
-	}
-
-	return mapping, nil
-}
-
 // processFiles walks through the extracted files and updates user emails
-func (u *Updater) processFiles(rootDir string, mapping UserMapping) error {
+func (u *Updater) processFiles(rootDir string, mapping *Mapping) error {
 	entries, err := os.ReadDir(rootDir)
 	if err != nil {
 		return fmt.Errorf("failed to read root directory: %w", err)
@@ -134,7 +150,7 @@ func (u *Updater) processFiles(rootDir string, mapping UserMapping) error {
 	}
 
 	// update the users.json file after processing all repositories
-	if err := u.updateUsersJson(rootDir, mapping); err != nil {
+	if err := u.updateUsersJson(rootDir); err != nil {
 		return fmt.Errorf("failed to update users.json: %w", err)
 	}
 
@@ -161,7 +177,7 @@ func (u *Updater) isValidOrgDir(path string) bool {
 }
 
 // processRepositories handles pull requests and rules for each repo in the org
-func (u *Updater) processRepositories(orgDir string, mapping UserMapping) error {
+func (u *Updater) processRepositories(orgDir string, mapping *Mapping) error {
 	repos, err := os.ReadDir(orgDir)
 	if err != nil {
 		return fmt.Errorf("failed to read org directory %s: %w", orgDir, err)
@@ -173,18 +189,21 @@ func (u *Updater) processRepositories(orgDir string, mapping UserMapping) error
 		}
 
 		repoDir := filepath.Join(orgDir, repo.Name())
-		if err := u.processPullRequests(repoDir, mapping); err != nil {
+		repoName := filepath.Base(orgDir) + "/" + repo.Name()
+		if err := u.processPullRequests(repoDir, repoName, mapping); err != nil {
 			return err
 		}
-		if err := u.processRules(repoDir, mapping); err != nil {
+		if err := u.processRules(repoDir, repoName, mapping); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// updateUsersJson updates emails in users.json based on the provided mapping
-func (u *Updater) updateUsersJson(rootDir string, mapping UserMapping) error {
+// updateUsersJson lists the users of users.json again from the updated
+// pull requests and rules. Rules matching the login cannot be applied to
+// the emails stored in users.json.
+func (u *Updater) updateUsersJson(rootDir string) error {
 	usersJsonPath := filepath.Join(rootDir, types.UsersFileName)
 	if _, err := os.Stat(usersJsonPath); err != nil {
 		u.tracer.Log("No users.json file found at the root directory")
@@ -193,24 +212,14 @@ func (u *Updater) updateUsersJson(rootDir string, mapping UserMapping) error {
 
 	u.tracer.Log("Updating users.json file at %s", usersJsonPath)
 
-	usersFileData, err := os.ReadFile(usersJsonPath)
-	if err != nil {
-		return fmt.Errorf("error reading users.json file: %w", err)
-	}
-
-	var usersInput types.CheckUsersInput
-	err = json.Unmarshal(usersFileData, &usersInput)
+	identities, err := listIdentities(rootDir)
 	if err != nil {
-		return fmt.Errorf("error unmarshalling provided user mapping file : %w. \nExpected format is {\"emailoriginal\": \"emailnew\", ...}", err)
+		return fmt.Errorf("error listing users: %w", err)
 	}
 
-	updatedEmails := make([]string, 0, len(usersInput.Emails))
-	for _, email := range usersInput.Emails {
-		if newEmail, exists := mapping[email]; exists {
-			updatedEmails = append(updatedEmails, newEmail)
-		} else {
-			updatedEmails = append(updatedEmails, email)
-		}
+	updatedEmails := make([]string, 0, len(identities))
+	for _, identity := range identities {
+		updatedEmails = append(updatedEmails, identity.Email)
 	}
 	updatedUsersInput := types.CheckUsersInput{
 		Emails: updatedEmails,
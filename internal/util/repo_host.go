@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/harness/harness-migrate/internal/types"
+)
+
+// Git providers used to select the repository connector.
+const (
+	ProviderGithub          = "github"
+	ProviderGitlab          = "gitlab"
+	ProviderBitbucket       = "bitbucket"
+	ProviderBitbucketServer = "stash"
+	ProviderAzure           = "azure"
+)
+
+// RepoHost describes the git provider hosting a repository.
+type RepoHost struct {
+	// Provider is the git provider, e.g. github or stash.
+	Provider string
+	// URL is the provider address used by the connector.
+	URL string
+	// Repo is the repository path relative to the URL.
+	Repo string
+	// Host is the hostname of the clone url.
+	Host string
+	// Namespace is the full path of the repository owner,
+	// e.g. a gitlab group and subgroups.
+	Namespace string
+	// Name is the repository name.
+	Name string
+}
+
+var errRepoPath = errors.New("expected a namespace and repository name")
+
+// ParseRepoURL returns the git provider, namespace and name of
+// the repository with the given http or ssh clone url.
+func ParseRepoURL(rawurl string) (*RepoHost, error) {
+	host := ParseRepoHost(rawurl, "")
+	if host.Host == "" {
+		return nil, fmt.Errorf("cannot parse repository url %q", rawurl)
+	}
+	if host.Namespace == "" || host.Name == "" {
+		return nil, fmt.Errorf("cannot parse repository url %q: %w", rawurl, errRepoPath)
+	}
+	return host, nil
+}
+
+// ParseRepoHost returns the git provider hosting the repository
+// with the given http or ssh clone url. The fallback provider
+// is used when the provider cannot be detected from the url.
+func ParseRepoHost(rawurl, fallback string) *RepoHost {
+	host := &RepoHost{Provider: fallback}
+
+	// convert scp-like ssh urls (git@host:path) to standard urls.
+	if !strings.Contains(rawurl, "://") && strings.Contains(rawurl, ":") {
+		rawurl = "ssh://" + strings.Replace(rawurl, ":", "/", 1)
+	}
+	u, err := url.Parse(rawurl)
+	if err != nil || u.Host == "" {
+		return host
+	}
+
+	scheme := u.Scheme
+	address := u.Host
+	if scheme != "http" && scheme != "https" {
+		scheme = "https"
+		address = u.Hostname()
+	}
+	base := scheme + "://" + address
+	path := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	parts := strings.Split(path, "/")
+	host.Host = u.Hostname()
+	host.Namespace, host.Name = splitRepoPath(path)
+
+	switch hostname := strings.ToLower(u.Hostname()); {
+	case hostname == "github.com":
+		host.Provider, host.URL, host.Repo = ProviderGithub, "https://github.com", path
+	case hostname == "gitlab.com":
+		host.Provider, host.URL, host.Repo = ProviderGitlab, "https://gitlab.com", path
+	case hostname == "bitbucket.org":
+		host.Provider, host.URL, host.Repo = ProviderBitbucket, "https://bitbucket.org", path
+	case hostname == "dev.azure.com" || hostname == "ssh.dev.azure.com":
+		// https://dev.azure.com/org/project/_git/repo
+		// ssh://git@ssh.dev.azure.com/v3/org/project/repo
+		parts = removeSegment(parts, "_git")
+		if len(parts) > 0 && parts[0] == "v3" {
+			parts = parts[1:]
+		}
+		if len(parts) == 3 {
+			host.Provider = ProviderAzure
+			host.URL = "https://dev.azure.com/" + parts[0] + "/" + parts[1]
+			host.Repo = parts[2]
+			host.Namespace, host.Name = parts[0]+"/"+parts[1], parts[2]
+		}
+	case strings.HasSuffix(hostname, ".visualstudio.com"):
+		// https://org.visualstudio.com/[DefaultCollection/]project/_git/repo
+		parts = removeSegment(parts, "_git")
+		if len(parts) > 0 && parts[0] == "DefaultCollection" {
+			parts = parts[1:]
+		}
+		if len(parts) == 2 {
+			host.Provider = ProviderAzure
+			host.URL = "https://dev.azure.com/" + strings.TrimSuffix(hostname, ".visualstudio.com") + "/" + parts[0]
+			host.Repo = parts[1]
+			host.Namespace, host.Name = strings.TrimSuffix(hostname, ".visualstudio.com")+"/"+parts[0], parts[1]
+		}
+	default:
+		host.URL = base
+		host.Repo = path
+		// bitbucket cloud is only hosted on bitbucket.org, other
+		// hosts are self-hosted bitbucket server instances.
+		if host.Provider == ProviderBitbucket {
+			host.Provider = ProviderBitbucketServer
+		}
+		// bitbucket server http clone urls have the form
+		// https://host/[context/]scm/project/repo.git
+		for i, part := range parts {
+			if part == "scm" && len(parts)-i == 3 {
+				host.Provider = ProviderBitbucketServer
+				host.URL = JoinPaths(append([]string{base}, parts[:i+1]...)...)
+				host.Repo = strings.Join(parts[i+1:], "/")
+				host.Namespace, host.Name = parts[i+1], parts[i+2]
+				break
+			}
+		}
+	}
+	return host
+}
+
+// helper function splits the repository path into the
+// namespace and the repository name.
+func splitRepoPath(path string) (string, string) {
+	i := strings.LastIndex(path, "/")
+	if i == -1 {
+		return "", path
+	}
+	return path[:i], path[i+1:]
+}
+
+// helper function removes the first occurrence of the segment.
+func removeSegment(parts []string, segment string) []string {
+	for i, part := range parts {
+		if part == segment {
+			return append(parts[:i:i], parts[i+1:]...)
+		}
+	}
+	return parts
+}
+
+// FirstRepo returns the clone url of the first project
+// with a repository.
+func FirstRepo(data *types.Org) string {
+	for _, project := range data.Projects {
+		if project.Repo != "" {
+			return project.Repo
+		}
+	}
+	return ""
+}
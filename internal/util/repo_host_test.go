@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/harness/harness-migrate/internal/harness"
+)
+
+func TestParseRepoHost(t *testing.T) {
+	tests := []struct {
+		url  string
+		want RepoHost
+	}{
+		{
+			url:  "https://github.com/octocat/hello-world.git",
+			want: RepoHost{ProviderGithub, "https://github.com", "octocat/hello-world", "github.com", "octocat", "hello-world"},
+		},
+		{
+			url:  "git@gitlab.com:group/sub/project.git",
+			want: RepoHost{ProviderGitlab, "https://gitlab.com", "group/sub/project", "gitlab.com", "group/sub", "project"},
+		},
+		{
+			url:  "https://user@bitbucket.org/workspace/repo.git",
+			want: RepoHost{ProviderBitbucket, "https://bitbucket.org", "workspace/repo", "bitbucket.org", "workspace", "repo"},
+		},
+		{
+			url:  "https://stash.company.com/scm/proj/repo.git",
+			want: RepoHost{ProviderBitbucketServer, "https://stash.company.com/scm", "proj/repo", "stash.company.com", "proj", "repo"},
+		},
+		{
+			url:  "http://stash.company.com:7990/bitbucket/scm/proj/repo.git",
+			want: RepoHost{ProviderBitbucketServer, "http://stash.company.com:7990/bitbucket/scm", "proj/repo", "stash.company.com", "proj", "repo"},
+		},
+		{
+			url:  "https://org@dev.azure.com/org/project/_git/repo",
+			want: RepoHost{ProviderAzure, "https://dev.azure.com/org/project", "repo", "dev.azure.com", "org/project", "repo"},
+		},
+		{
+			url:  "git@ssh.dev.azure.com:v3/org/project/repo",
+			want: RepoHost{ProviderAzure, "https://dev.azure.com/org/project", "repo", "ssh.dev.azure.com", "org/project", "repo"},
+		},
+		{
+			url:  "https://org.visualstudio.com/DefaultCollection/project/_git/repo",
+			want: RepoHost{ProviderAzure, "https://dev.azure.com/org/project", "repo", "org.visualstudio.com", "org/project", "repo"},
+		},
+		{
+			url:  "https://github.company.com/octocat/hello-world.git",
+			want: RepoHost{ProviderGithub, "https://github.company.com", "octocat/hello-world", "github.company.com", "octocat", "hello-world"},
+		},
+		{
+			url:  "",
+			want: RepoHost{Provider: ProviderGithub},
+		},
+	}
+	for _, test := range tests {
+		got := ParseRepoHost(test.url, ProviderGithub)
+		if *got != test.want {
+			t.Errorf("Want host %+v for %q, got %+v", test.want, test.url, *got)
+		}
+	}
+}
+
+func TestParseRepoHostBitbucketServer(t *testing.T) {
+	got := ParseRepoHost("ssh://git@stash.company.com:7999/proj/repo.git", ProviderBitbucket)
+	want := RepoHost{ProviderBitbucketServer, "https://stash.company.com", "proj/repo", "stash.company.com", "proj", "repo"}
+	if *got != want {
+		t.Errorf("Want host %+v, got %+v", want, *got)
+	}
+}
+
+func TestCreateRepoConnectorBitbucket(t *testing.T) {
+	tests := map[string]string{
+		"https://bitbucket.org/workspace/repo.git":       "https://bitbucket.org",
+		"ssh://git@stash.company.com:7999/proj/repo.git": "https://stash.company.com",
+	}
+	for rawurl, want := range tests {
+		host := ParseRepoHost(rawurl, ProviderBitbucket)
+		conn := CreateRepoConnector("acme", "bitbucket", host, "octocat", "org.bitbucket")
+		if got := conn.Spec.(*harness.ConnectorBitbucket).URL; got != want {
+			t.Errorf("Want connector url %q for %q, got %q", want, rawurl, got)
+		}
+	}
+}
+
+func TestParseRepoURL(t *testing.T) {
+	for _, rawurl := range []string{"", "not a url", "https://github.com/hello-world.git"} {
+		if _, err := ParseRepoURL(rawurl); err == nil {
+			t.Errorf("Want error parsing %q", rawurl)
+		}
+	}
+}
@@ -51,6 +51,26 @@ func CreateSecretOrg(org, identifier, data string) *harness.Secret {
 	return CreateSecret(org, "", identifier, "", data)
 }
 
+// CreateVariable helper function to create a project variable.
+func CreateVariable(org, project, identifier, name, value string) *harness.Variable {
+	return &harness.Variable{
+		Name:              name,
+		Identifier:        identifier,
+		Orgidentifier:     org,
+		Projectidentifier: project,
+		Type:              harness.DefaultVariableType,
+		Spec: &harness.VariableSpec{
+			Type:  harness.DefaultVariableValueType,
+			Value: value,
+		},
+	}
+}
+
+// CreateVariableOrg helper function to create an org variable.
+func CreateVariableOrg(org, identifier, name, value string) *harness.Variable {
+	return CreateVariable(org, "", identifier, name, value)
+}
+
 // CreateGithubConnector helper function to create a github connector
 func CreateGithubConnector(org, id, username, token string) *harness.Connector {
 	return &harness.Connector{
@@ -111,6 +131,111 @@ func CreateGitlabConnector(org, id, username, token string) *harness.Connector {
 	}
 }
 
+// CreateBitbucketConnector helper function to create a bitbucket cloud connector
+func CreateBitbucketConnector(org, id, username, token string) *harness.Connector {
+	return createBitbucketConnector(org, id, "https://bitbucket.org", username, token)
+}
+
+// CreateBitbucketServerConnector helper function to create a bitbucket server
+// connector. The url is the server address including the /scm path.
+func CreateBitbucketServerConnector(org, id, url, username, token string) *harness.Connector {
+	return createBitbucketConnector(org, id, url, username, token)
+}
+
+func createBitbucketConnector(org, id, url, username, token string) *harness.Connector {
+	return &harness.Connector{
+		Name:          id,
+		Identifier:    id,
+		Orgidentifier: org,
+		Type:          harness.ConnectorTypeBitbucket,
+		Spec: &harness.ConnectorBitbucket{
+			Type: "Account",
+			URL:  url,
+			Authentication: &harness.Resource{
+				Type: "Http",
+				Spec: &harness.Resource{
+					Type: "UsernamePassword",
+					Spec: &harness.ConnectorPassword{
+						Username:    username,
+						Passwordref: token,
+					},
+				},
+			},
+			Apiaccess: &harness.Resource{
+				Type: "UsernameToken",
+				Spec: &harness.ConnectorToken{
+					Username: username,
+					Tokenref: token,
+				},
+			},
+		},
+	}
+}
+
+// CreateAzureRepoConnector helper function to create an azure repos connector
+// for the project with the given url (https://dev.azure.com/org/project).
+func CreateAzureRepoConnector(org, id, url, username, token string) *harness.Connector {
+	return &harness.Connector{
+		Name:          id,
+		Identifier:    id,
+		Orgidentifier: org,
+		Type:          harness.ConnectorTypeAzureRepo,
+		Spec: &harness.ConnectorAzureRepo{
+			Type: "Project",
+			URL:  url,
+			Authentication: &harness.Resource{
+				Type: "Http",
+				Spec: &harness.Resource{
+					Type: "UsernameToken",
+					Spec: &harness.ConnectorToken{
+						Username: username,
+						Tokenref: token,
+					},
+				},
+			},
+			Apiaccess: &harness.Resource{
+				Type: "Token",
+				Spec: &harness.ConnectorToken{
+					Tokenref: token,
+				},
+			},
+		},
+	}
+}
+
+// CreateRepoConnector helper function to create a connector for the
+// git provider hosting the source repositories.
+func CreateRepoConnector(org, id string, host *RepoHost, username, token string) *harness.Connector {
+	var conn *harness.Connector
+	switch host.Provider {
+	case ProviderGitlab:
+		conn = CreateGitlabConnector(org, id, username, token)
+		if host.URL != "" {
+			conn.Spec.(*harness.ConnectorGitlab).URL = host.URL
+		}
+		conn.Spec.(*harness.ConnectorGitlab).Validationrepo = host.Repo
+	case ProviderBitbucket, ProviderBitbucketServer:
+		// only bitbucket.org is bitbucket cloud, any other
+		// host is a bitbucket server instance.
+		if host.Host == "" || strings.EqualFold(host.Host, "bitbucket.org") {
+			conn = CreateBitbucketConnector(org, id, username, token)
+		} else {
+			conn = CreateBitbucketServerConnector(org, id, host.URL, username, token)
+		}
+		conn.Spec.(*harness.ConnectorBitbucket).Validationrepo = host.Repo
+	case ProviderAzure:
+		conn = CreateAzureRepoConnector(org, id, host.URL, username, token)
+		conn.Spec.(*harness.ConnectorAzureRepo).Validationrepo = host.Repo
+	default:
+		conn = CreateGithubConnector(org, id, username, token)
+		if host.URL != "" {
+			conn.Spec.(*harness.ConnectorGithub).URL = host.URL
+		}
+		conn.Spec.(*harness.ConnectorGithub).Validationrepo = host.Repo
+	}
+	return conn
+}
+
 // CreateDockerConnector helper function to create a docker connector
 func CreateDockerConnector(org, id string, args ...interface{}) *harness.Connector {
 	var authentication *harness.Resource
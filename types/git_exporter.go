@@ -29,6 +29,8 @@ const (
 	BranchRulesFileName           = "branch_rules.json"
 	LabelsFileName                = "labels.json"
 	UsersFileName                 = "users.json"
+	AttachmentsFileName           = "attachments.json"
+	AttachmentsDir                = "attachments"
 	RuleTypeBranch       RuleType = "branch"
 )
 
@@ -50,6 +52,19 @@ type (
 		IsEmpty        bool       `json:"is_empty"`
 		GitLFSDisabled bool       `json:"git_lfs_disabled"`
 		LfsObjectCount int        `json:"lfs_object_count"`
+
+		Description   string         `json:"description,omitempty"`
+		Topics        []string       `json:"topics,omitempty"`
+		Homepage      string         `json:"homepage,omitempty"`
+		MergeSettings *MergeSettings `json:"merge_settings,omitempty"`
+	}
+
+	// MergeSettings represents the repository-wide pull
+	// request merge settings.
+	MergeSettings struct {
+		StrategiesAllowed []string `json:"strategies_allowed,omitempty"`
+		DeleteBranch      bool     `json:"delete_branch,omitempty"`
+		TitleFormat       string   `json:"title_format,omitempty"`
 	}
 
 	Perm struct {
@@ -81,6 +96,21 @@ type (
 		Created time.Time `json:"created"`
 		Updated time.Time `json:"updated"`
 		Labels  []Label   `json:"labels"`
+
+		// Metadata of the source pull request which has no
+		// direct counterpart on Harness.
+		Assignees    []User   `json:"assignees,omitempty"`
+		Milestone    string   `json:"milestone,omitempty"`
+		LinkedIssues []string `json:"linked_issues,omitempty"`
+		Squash       bool     `json:"squash,omitempty"`
+		AutoMerge    bool     `json:"auto_merge,omitempty"`
+	}
+
+	// Attachment is a file referenced from a pull request or
+	// comment body which is stored in the archive.
+	Attachment struct {
+		URL  string `json:"url"`  // link as it appears in the body
+		File string `json:"file"` // path relative to the repository folder
 	}
 
 	Label struct {
@@ -131,6 +161,11 @@ type (
 		Updated     time.Time    `json:"updated"`
 		ParentID    int          `json:"parent_id"`
 		CodeComment *CodeComment `json:"code_comment"`
+
+		// Resolved and ResolvedBy are set on the first comment
+		// of a resolved review thread.
+		Resolved   *time.Time `json:"resolved,omitempty"`
+		ResolvedBy *User      `json:"resolved_by,omitempty"`
 	}
 
 	Review struct {
@@ -147,6 +182,25 @@ type (
 		User User `json:"user"`
 	}
 
+	// ActivityType is the type of a pull request activity.
+	ActivityType string
+
+	// Activity is an event on the timeline of a pull request which
+	// is neither a comment nor a review, e.g. a state change.
+	Activity struct {
+		Type    ActivityType `json:"type"`
+		Actor   User         `json:"actor"`
+		Created time.Time    `json:"created"`
+
+		// From and To are the head SHAs before and after a force
+		// push. To is the merge commit SHA of a merge.
+		From string `json:"from,omitempty"`
+		To   string `json:"to,omitempty"`
+
+		// Method is the merge method of a merge, if known.
+		Method string `json:"method,omitempty"`
+	}
+
 	RuleType string
 
 	BranchRule struct {
@@ -184,6 +238,7 @@ type (
 		Comments    []Comment   `json:"comments"`
 		Reviews     []Review    `json:"reviews"`
 		Reviewers   []Reviewer  `json:"reviewers"`
+		Activities  []Activity  `json:"activities,omitempty"`
 	}
 
 	WebhookData struct {
@@ -241,6 +296,16 @@ type (
 	}
 )
 
+// Activity types.
+const (
+	ActivityClosed         ActivityType = "closed"
+	ActivityReopened       ActivityType = "reopened"
+	ActivityMerged         ActivityType = "merged"
+	ActivityForcePushed    ActivityType = "force-pushed"
+	ActivityReadyForReview ActivityType = "ready-for-review"
+	ActivityDraft          ActivityType = "draft"
+)
+
 // Role values.
 const (
 	VisibilityUndefined Visibility = iota